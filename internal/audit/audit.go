@@ -0,0 +1,200 @@
+// Package audit keeps an append-only JSONL log of every mutating GitHub call
+// so it can later be answered who changed which pull request, and when.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Action is the kind of mutating call recorded in the log.
+type Action string
+
+const (
+	ActionMerge         Action = "merge"
+	ActionUpdateBranch  Action = "update_branch"
+	ActionRebaseComment Action = "rebase_comment"
+	ActionComment       Action = "comment"
+	ActionUpdateComment Action = "update_comment"
+	ActionDeleteComment Action = "delete_comment"
+	ActionUpdateBody    Action = "update_body"
+	ActionAddLabels     Action = "add_labels"
+	ActionRemoveLabel   Action = "remove_label"
+	ActionClose         Action = "close"
+	ActionDeleteBranch  Action = "delete_branch"
+)
+
+// Actions lists every recorded action.
+var Actions = []Action{
+	ActionMerge, ActionUpdateBranch, ActionRebaseComment, ActionComment, ActionUpdateComment, ActionDeleteComment,
+	ActionUpdateBody, ActionAddLabels, ActionRemoveLabel, ActionClose, ActionDeleteBranch,
+}
+
+// Outcome is whether the recorded call succeeded.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+)
+
+// Entry is one line of the audit log.
+type Entry struct {
+	Time        time.Time `json:"time"`
+	Actor       string    `json:"actor"`
+	Repository  string    `json:"repository"`
+	PullRequest int       `json:"pull_request,omitempty"`
+	HeadSHA     string    `json:"head_sha,omitempty"`
+	Action      Action    `json:"action"`
+	Detail      string    `json:"detail,omitempty"`
+	Command     string    `json:"command"`
+	Outcome     Outcome   `json:"outcome"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Off disables the audit log when given as its path.
+const Off = "off"
+
+// DefaultPath returns the audit log used when --audit-log is not given:
+// GHPRMERGE_AUDIT_LOG, or audit.jsonl in the user's ghprmerge config
+// directory. Without either it returns an error rather than a path in the
+// working directory, which in CI is usually a checked-out repository.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("GHPRMERGE_AUDIT_LOG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no default audit log location (%w); set --audit-log or GHPRMERGE_AUDIT_LOG", err)
+	}
+	return filepath.Join(dir, "ghprmerge", "audit.jsonl"), nil
+}
+
+// Log appends entries to an audit log file. Each entry is written with a
+// single append so concurrent runs do not interleave lines. The first write
+// error is kept and reported by Err.
+type Log struct {
+	mu   sync.Mutex
+	file *os.File
+	err  error
+}
+
+// Open opens the audit log at path for appending, creating it and its
+// directory when missing. The file is readable only by its owner.
+func Open(path string) (*Log, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &Log{file: file}, nil
+}
+
+// Write appends an entry to the log.
+func (l *Log) Write(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return
+	}
+	data, err := json.Marshal(entry)
+	if err == nil {
+		_, err = l.file.Write(append(data, '\n'))
+	}
+	if err != nil {
+		l.err = fmt.Errorf("failed to write audit log: %w", err)
+	}
+}
+
+// Err returns the first error from writing the log.
+func (l *Log) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	return l.file.Close()
+}
+
+// Filter selects audit log entries. Zero fields match everything.
+type Filter struct {
+	Repository string    // full name, or a repository name within any owner
+	Since      time.Time // inclusive
+	Until      time.Time // exclusive
+	Actions    []Action
+}
+
+// Match reports whether an entry passes the filter.
+func (f Filter) Match(entry Entry) bool {
+	if f.Repository != "" && entry.Repository != f.Repository && !strings.HasSuffix(entry.Repository, "/"+f.Repository) {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Time.Before(f.Until) {
+		return false
+	}
+	return len(f.Actions) == 0 || slices.Contains(f.Actions, entry.Action)
+}
+
+// Read returns the entries of the audit log at path that match the filter, in
+// the order they were written. A missing log has no entries.
+func Read(path string, filter Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid audit entry: %w", path, line, err)
+		}
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// ParseTime parses a --since or --until value: a UTC date (YYYY-MM-DD) or an
+// RFC 3339 timestamp. A date as the end of a range covers that whole day.
+func ParseTime(value string, end bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use YYYY-MM-DD or an RFC 3339 timestamp", value)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
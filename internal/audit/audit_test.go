@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+func TestLogReadFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "audit.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	day := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	log.Write(Entry{Time: day, Repository: "myorg/api", PullRequest: 1, Action: ActionMerge, Outcome: OutcomeSuccess})
+	log.Write(Entry{Time: day.AddDate(0, 0, 1), Repository: "myorg/web", PullRequest: 2, Action: ActionClose, Outcome: OutcomeSuccess})
+	log.Write(Entry{Time: day.AddDate(0, 0, 2), Repository: "myorg/api", PullRequest: 3, Action: ActionUpdateBranch, Outcome: OutcomeFailure, Error: "boom"})
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := log.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	// Reopening appends instead of truncating.
+	log, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	log.Write(Entry{Time: day.AddDate(0, 0, 3), Repository: "other/api", PullRequest: 4, Action: ActionMerge, Outcome: OutcomeSuccess})
+	log.Close()
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []int
+	}{
+		{"all", Filter{}, []int{1, 2, 3, 4}},
+		{"repo name", Filter{Repository: "api"}, []int{1, 3, 4}},
+		{"full name", Filter{Repository: "myorg/api"}, []int{1, 3}},
+		{"since", Filter{Since: day.AddDate(0, 0, 1)}, []int{2, 3, 4}},
+		{"until", Filter{Until: day.AddDate(0, 0, 1)}, []int{1}},
+		{"actions", Filter{Actions: []Action{ActionMerge, ActionClose}}, []int{1, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Read(path, tt.filter)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			var got []int
+			for _, e := range entries {
+				got = append(got, e.PullRequest)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Read() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Read() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReadMissingLog(t *testing.T) {
+	entries, err := Read(filepath.Join(t.TempDir(), "missing.jsonl"), Filter{})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Read() = %v, %v, want no entries", entries, err)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteJSON() is not valid JSON: %v", err)
+	}
+	if doc["schema_version"] != float64(output.SchemaVersion) {
+		t.Errorf("schema_version = %v, want %d", doc["schema_version"], output.SchemaVersion)
+	}
+	if entries, ok := doc["entries"].([]any); !ok || len(entries) != 0 {
+		t.Errorf("entries = %v, want an empty array", doc["entries"])
+	}
+	if _, err := output.JSONSchema("audit", output.CasingCamel); err != nil {
+		t.Errorf("JSONSchema(audit) error = %v", err)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	start, err := ParseTime("2026-03-01", false)
+	if err != nil {
+		t.Fatalf("ParseTime() error = %v", err)
+	}
+	end, err := ParseTime("2026-03-01", true)
+	if err != nil {
+		t.Fatalf("ParseTime() error = %v", err)
+	}
+	if got := end.Sub(start); got != 24*time.Hour {
+		t.Errorf("end of day - start of day = %v, want 24h", got)
+	}
+	exact, err := ParseTime("2026-03-01T10:00:00Z", true)
+	if err != nil || !exact.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseTime(RFC 3339) = %v, %v", exact, err)
+	}
+	if _, err := ParseTime("yesterday", false); err == nil {
+		t.Error("ParseTime() accepted an invalid value")
+	}
+}
@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+)
+
+// Client wraps a GitHub client and records every mutating call to the audit
+// log. Read calls pass through; the pull requests they return are remembered
+// so each entry can name the head SHA the decision was based on.
+type Client struct {
+	gh.Client
+	log     *Log
+	command string
+	now     func() time.Time
+
+	mu        sync.Mutex
+	actor     string
+	actorDone bool
+	heads     map[string]gh.PullRequest // key: "owner/repo#number"
+}
+
+// NewClient returns a client that records the mutations made through client.
+// command is the command line recorded with each entry.
+func NewClient(client gh.Client, log *Log, command string) *Client {
+	return &Client{
+		Client:  client,
+		log:     log,
+		command: command,
+		now:     time.Now,
+		heads:   make(map[string]gh.PullRequest),
+	}
+}
+
+// ListPullRequests lists pull requests and remembers their heads.
+func (c *Client) ListPullRequests(ctx context.Context, owner, repo, defaultBranch string) ([]gh.PullRequest, error) {
+	prs, err := c.Client.ListPullRequests(ctx, owner, repo, defaultBranch)
+	for _, pr := range prs {
+		c.remember(owner, repo, pr)
+	}
+	return prs, err
+}
+
+// GetPullRequest gets a pull request and remembers its head.
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*gh.PullRequest, error) {
+	pr, err := c.Client.GetPullRequest(ctx, owner, repo, number)
+	if pr != nil {
+		c.remember(owner, repo, *pr)
+	}
+	return pr, err
+}
+
+// UpdateBranch updates a pull request branch and records it.
+func (c *Client) UpdateBranch(ctx context.Context, owner, repo string, prNumber int) error {
+	err := c.Client.UpdateBranch(ctx, owner, repo, prNumber)
+	c.record(ctx, owner, repo, prNumber, ActionUpdateBranch, "", err)
+	return err
+}
+
+// PostRebaseComment posts a rebase comment and records it.
+func (c *Client) PostRebaseComment(ctx context.Context, owner, repo string, prNumber int) error {
+	err := c.Client.PostRebaseComment(ctx, owner, repo, prNumber)
+	c.record(ctx, owner, repo, prNumber, ActionRebaseComment, "", err)
+	return err
+}
+
+// PostComment posts a comment and records it.
+func (c *Client) PostComment(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	err := c.Client.PostComment(ctx, owner, repo, prNumber, body)
+	c.record(ctx, owner, repo, prNumber, ActionComment, firstLine(body), err)
+	return err
+}
+
+// UpdateComment edits a comment and records it.
+func (c *Client) UpdateComment(ctx context.Context, owner, repo string, prNumber int, commentID int64, body string) error {
+	err := c.Client.UpdateComment(ctx, owner, repo, prNumber, commentID, body)
+	c.record(ctx, owner, repo, prNumber, ActionUpdateComment, fmt.Sprintf("comment %d", commentID), err)
+	return err
+}
+
+// DeleteComment deletes a comment and records it.
+func (c *Client) DeleteComment(ctx context.Context, owner, repo string, prNumber int, commentID int64) error {
+	err := c.Client.DeleteComment(ctx, owner, repo, prNumber, commentID)
+	c.record(ctx, owner, repo, prNumber, ActionDeleteComment, fmt.Sprintf("comment %d", commentID), err)
+	return err
+}
+
+// UpdatePullRequestBody replaces a pull request description and records it.
+func (c *Client) UpdatePullRequestBody(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	err := c.Client.UpdatePullRequestBody(ctx, owner, repo, prNumber, body)
+	c.record(ctx, owner, repo, prNumber, ActionUpdateBody, "", err)
+	return err
+}
+
+// AddLabels adds labels to a pull request and records it.
+func (c *Client) AddLabels(ctx context.Context, owner, repo string, prNumber int, labels []string) error {
+	err := c.Client.AddLabels(ctx, owner, repo, prNumber, labels)
+	c.record(ctx, owner, repo, prNumber, ActionAddLabels, strings.Join(labels, ","), err)
+	return err
+}
+
+// RemoveLabel removes a label from a pull request and records it.
+func (c *Client) RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error {
+	err := c.Client.RemoveLabel(ctx, owner, repo, prNumber, label)
+	c.record(ctx, owner, repo, prNumber, ActionRemoveLabel, label, err)
+	return err
+}
+
+// MergePullRequest merges a pull request and records it.
+func (c *Client) MergePullRequest(ctx context.Context, owner, repo string, prNumber int) error {
+	err := c.Client.MergePullRequest(ctx, owner, repo, prNumber)
+	c.record(ctx, owner, repo, prNumber, ActionMerge, "", err)
+	return err
+}
+
+// ClosePullRequest closes a pull request and records it.
+func (c *Client) ClosePullRequest(ctx context.Context, owner, repo string, prNumber int) error {
+	err := c.Client.ClosePullRequest(ctx, owner, repo, prNumber)
+	c.record(ctx, owner, repo, prNumber, ActionClose, "", err)
+	return err
+}
+
+// DeleteBranch deletes a branch and records it against the pull request that
+// had the branch as its head, when one was seen.
+func (c *Client) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	err := c.Client.DeleteBranch(ctx, owner, repo, branch)
+	number := 0
+	c.mu.Lock()
+	for _, pr := range c.heads {
+		if pr.RepoFullName == owner+"/"+repo && pr.HeadBranch == branch {
+			number = pr.Number
+			break
+		}
+	}
+	c.mu.Unlock()
+	c.record(ctx, owner, repo, number, ActionDeleteBranch, branch, err)
+	return err
+}
+
+// remember keeps the head of a pull request for later entries.
+func (c *Client) remember(owner, repo string, pr gh.PullRequest) {
+	pr.RepoFullName = owner + "/" + repo
+	c.mu.Lock()
+	c.heads[fmt.Sprintf("%s/%s#%d", owner, repo, pr.Number)] = pr
+	c.mu.Unlock()
+}
+
+// record writes the entry for a mutating call.
+func (c *Client) record(ctx context.Context, owner, repo string, number int, action Action, detail string, err error) {
+	entry := Entry{
+		Time:        c.now().UTC(),
+		Actor:       c.actorLogin(ctx),
+		Repository:  owner + "/" + repo,
+		PullRequest: number,
+		Action:      action,
+		Detail:      detail,
+		Command:     c.command,
+		Outcome:     OutcomeSuccess,
+	}
+	if number != 0 {
+		c.mu.Lock()
+		entry.HeadSHA = c.heads[fmt.Sprintf("%s/%s#%d", owner, repo, number)].HeadSHA
+		c.mu.Unlock()
+	}
+	if err != nil {
+		entry.Outcome = OutcomeFailure
+		entry.Error = err.Error()
+	}
+	c.log.Write(entry)
+}
+
+// actorLogin looks up the token owner once, on the first mutation, so runs
+// that change nothing make no extra API call. Tokens that cannot read their
+// user, such as some GitHub App tokens, are recorded as "unknown".
+func (c *Client) actorLogin(ctx context.Context) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.actorDone {
+		login, err := c.Client.AuthenticatedUser(ctx)
+		if err != nil || login == "" {
+			login = "unknown"
+		}
+		c.actor, c.actorDone = login, true
+	}
+	return c.actor
+}
+
+// firstLine returns the first line of a comment body, which names the bot
+// command or the status marker.
+func firstLine(body string) string {
+	line, _, _ := strings.Cut(body, "\n")
+	return line
+}
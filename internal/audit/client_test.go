@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+)
+
+func TestClientRecordsMutations(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.Login = "alice"
+	mock.PullRequests["myorg/api"] = []gh.PullRequest{
+		{Number: 7, HeadBranch: "deps/foo", HeadSHA: "abc1234def"},
+	}
+	mock.CloseErr["myorg/api/"+string(rune(7))] = errors.New("forbidden")
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	client := NewClient(mock, log, "ghprmerge close --org myorg")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if _, err := client.ListPullRequests(ctx, "myorg", "api", "main"); err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+	client.MergePullRequest(ctx, "myorg", "api", 7)
+	client.ClosePullRequest(ctx, "myorg", "api", 7)
+	client.DeleteBranch(ctx, "myorg", "api", "deps/foo")
+	client.GetCheckStatus(ctx, "myorg", "api", "abc1234def")
+	log.Close()
+
+	entries, err := Read(path, Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("recorded %d entries, want 3 (reads are not recorded)", len(entries))
+	}
+
+	merge := entries[0]
+	want := Entry{Time: now, Actor: "alice", Repository: "myorg/api", PullRequest: 7, HeadSHA: "abc1234def",
+		Action: ActionMerge, Command: "ghprmerge close --org myorg", Outcome: OutcomeSuccess}
+	if merge != want {
+		t.Errorf("merge entry = %+v, want %+v", merge, want)
+	}
+	if closeEntry := entries[1]; closeEntry.Action != ActionClose || closeEntry.Outcome != OutcomeFailure || closeEntry.Error != "forbidden" {
+		t.Errorf("close entry = %+v, want failed close", closeEntry)
+	}
+	if del := entries[2]; del.Action != ActionDeleteBranch || del.PullRequest != 7 || del.Detail != "deps/foo" || del.HeadSHA != "abc1234def" {
+		t.Errorf("delete entry = %+v, want branch of PR #7", del)
+	}
+}
+
+func TestClientUnknownActor(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.LoginErr = errors.New("resource not accessible by integration")
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	NewClient(mock, log, "ghprmerge merge").UpdateBranch(context.Background(), "myorg", "api", 1)
+	log.Close()
+
+	entries, _ := Read(path, Filter{})
+	if len(entries) != 1 || entries[0].Actor != "unknown" {
+		t.Errorf("entries = %+v, want actor unknown", entries)
+	}
+}
+
+func TestClientRecordsCommentEditsWithPullRequest(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.PullRequests["myorg/api"] = []gh.PullRequest{
+		{Number: 7, HeadBranch: "deps/foo", HeadSHA: "abc1234def"},
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	client := NewClient(mock, log, "ghprmerge merge --comment-on-skip")
+	ctx := context.Background()
+	if _, err := client.ListPullRequests(ctx, "myorg", "api", "main"); err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+	client.UpdateComment(ctx, "myorg", "api", 7, 42, "updated")
+	client.DeleteComment(ctx, "myorg", "api", 7, 42)
+	log.Close()
+
+	entries, err := Read(path, Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("recorded %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.PullRequest != 7 || entry.HeadSHA != "abc1234def" || entry.Detail != "comment 42" {
+			t.Errorf("entry = %+v, want comment 42 on PR #7 at abc1234def", entry)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"text/tabwriter"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// Document is the JSON document written by audit --json.
+type Document struct {
+	SchemaVersion int     `json:"schema_version"`
+	Entries       []Entry `json:"entries"`
+}
+
+func init() {
+	output.RegisterSchema("audit", "ghprmerge audit log entries", reflect.TypeFor[Document]())
+}
+
+// WriteText writes entries as an aligned table, oldest first.
+func WriteText(w io.Writer, entries []Entry) error {
+	if len(entries) == 0 {
+		_, err := fmt.Fprintln(w, "No audit entries found.")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tACTOR\tACTION\tTARGET\tHEAD\tOUTCOME\tDETAIL")
+	for _, e := range entries {
+		target := e.Repository
+		if e.PullRequest != 0 {
+			target = fmt.Sprintf("%s#%d", e.Repository, e.PullRequest)
+		}
+		head := e.HeadSHA
+		if len(head) > 7 {
+			head = head[:7]
+		}
+		detail := e.Detail
+		if e.Error != "" {
+			detail = e.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.UTC().Format(time.DateTime), e.Actor, e.Action, target, head, e.Outcome, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d entries\n", len(entries))
+	return err
+}
+
+// WriteJSON writes entries as a Document, oldest first.
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Document{SchemaVersion: output.SchemaVersion, Entries: entries})
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// AgeFlag is a flag holding a pull request age, such as 14d, 36h, or 90m.
+type AgeFlag time.Duration
+
+func (a *AgeFlag) String() string {
+	if a == nil || *a == 0 {
+		return ""
+	}
+	return output.FormatAge(time.Duration(*a))
+}
+
+func (a *AgeFlag) Set(value string) error {
+	age, err := parseAge(value)
+	if err != nil {
+		return err
+	}
+	*a = AgeFlag(age)
+	return nil
+}
+
+// parseAge parses a positive age given in days ("14d") or as a Go duration
+// ("36h", "90m").
+func parseAge(value string) (time.Duration, error) {
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q: use days such as 14d or a duration such as 36h", value)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if age, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid age %q: use days such as 14d or a duration such as 36h", value)
+		}
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("invalid age %q: must be greater than zero", value)
+	}
+	return age, nil
+}
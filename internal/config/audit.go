@@ -0,0 +1,120 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/audit"
+)
+
+// parseAuditFlags parses the audit command: ghprmerge audit [flags]. It reads
+// the local audit log and needs neither an organization nor a token.
+func parseAuditFlags(args []string) (*Config, error) {
+	fs := flag.NewFlagSet(string(CommandAudit), flag.ContinueOnError)
+	fs.Usage = func() {
+		printAuditUsage(fs.Output())
+	}
+	auditLog := fs.String("audit-log", "", "Audit log to read (default GHPRMERGE_AUDIT_LOG or the user config directory)")
+	repo := fs.String("repo", "", "Only show entries for this repository (name or owner/name)")
+	since := fs.String("since", "", "Only show entries at or after this UTC date (YYYY-MM-DD) or RFC 3339 time")
+	until := fs.String("until", "", "Only show entries up to this date (inclusive) or before this RFC 3339 time")
+	actions := fs.String("action", "", "Comma-separated actions to show, e.g. merge,close")
+	jsonOutput := fs.Bool("json", false, "Output the entries as a JSON array")
+	format := fs.String("format", "", "Output format: text or json")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("audit takes no arguments, got %q", fs.Arg(0))
+	}
+
+	var filter audit.Filter
+	filter.Repository = *repo
+	var err error
+	if *since != "" {
+		if filter.Since, err = audit.ParseTime(*since, false); err != nil {
+			return nil, fmt.Errorf("--since: %w", err)
+		}
+	}
+	if *until != "" {
+		if filter.Until, err = audit.ParseTime(*until, true); err != nil {
+			return nil, fmt.Errorf("--until: %w", err)
+		}
+	}
+	for name := range strings.SplitSeq(*actions, ",") {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			filter.Actions = append(filter.Actions, audit.Action(trimmed))
+		}
+	}
+
+	return &Config{
+		Command:     CommandAudit,
+		AuditLog:    *auditLog,
+		AuditFilter: filter,
+		JSON:        *jsonOutput || *format == "json",
+		Format:      *format,
+	}, nil
+}
+
+// validateAudit checks the audit command's filters and format.
+func (c *Config) validateAudit() error {
+	for _, action := range c.AuditFilter.Actions {
+		if !slices.Contains(audit.Actions, action) {
+			return fmt.Errorf("unknown --action %q: must be one of %s", action, auditActionList())
+		}
+	}
+	if !c.AuditFilter.Since.IsZero() && !c.AuditFilter.Until.IsZero() && !c.AuditFilter.Since.Before(c.AuditFilter.Until) {
+		return fmt.Errorf("--since must be before --until")
+	}
+	switch c.Format {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("--format must be one of: text, json")
+	}
+	if c.AuditLog == audit.Off {
+		return fmt.Errorf("--audit-log off cannot be used with the audit command")
+	}
+	return nil
+}
+
+// AuditLogPath returns the audit log to use: --audit-log, or the default path.
+// It returns "" when the audit log is turned off, by the flag or by
+// GHPRMERGE_AUDIT_LOG=off, and an error when there is no default path.
+func (c *Config) AuditLogPath() (string, error) {
+	path := c.AuditLog
+	if path == "" {
+		var err error
+		if path, err = audit.DefaultPath(); err != nil {
+			return "", err
+		}
+	}
+	if path == audit.Off {
+		return "", nil
+	}
+	return path, nil
+}
+
+func auditActionList() string {
+	names := make([]string, len(audit.Actions))
+	for i, action := range audit.Actions {
+		names[i] = string(action)
+	}
+	return strings.Join(names, ", ")
+}
+
+func printAuditUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n  %s audit [flags]\n\n", commandName())
+	fmt.Fprintln(w, "audit lists the mutations recorded in the local audit log by merge, rebase, and close. It does not contact GitHub.")
+	fmt.Fprintln(w, "\nAudit flags:")
+	fmt.Fprintln(w, "  --audit-log <path>         Audit log to read (default GHPRMERGE_AUDIT_LOG or <config dir>/ghprmerge/audit.jsonl).")
+	fmt.Fprintln(w, "  --repo <repository>        Only show entries for this repository, as name or owner/name.")
+	fmt.Fprintln(w, "  --since <time>             Only show entries at or after this UTC date (YYYY-MM-DD) or RFC 3339 time.")
+	fmt.Fprintln(w, "  --until <time>             Only show entries up to this date (inclusive) or before this RFC 3339 time.")
+	fmt.Fprintf(w, "  --action <actions>         Comma-separated actions to show: %s.\n", auditActionList())
+	fmt.Fprintln(w, "  --json                     Output the entries as a JSON array.")
+	fmt.Fprintln(w, "  --format <format>          Output format: text (default) or json.")
+}
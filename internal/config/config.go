@@ -11,8 +11,14 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/audit"
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
 )
 
 // StringSliceFlag is a custom flag type that collects multiple string values.
@@ -31,11 +37,14 @@ func (s *StringSliceFlag) Set(value string) error {
 type Command string
 
 const (
-	CommandNone   Command = ""
-	CommandMerge  Command = "merge"
-	CommandRebase Command = "rebase"
-	CommandReport Command = "report"
-	CommandClose  Command = "close"
+	CommandNone    Command = ""
+	CommandMerge   Command = "merge"
+	CommandRebase  Command = "rebase"
+	CommandReport  Command = "report"
+	CommandClose   Command = "close"
+	CommandSchema  Command = "schema"
+	CommandAudit   Command = "audit"
+	CommandHistory Command = "history"
 )
 
 type CommandDescription struct {
@@ -60,6 +69,18 @@ var commandDescriptions = []CommandDescription{
 		Name:        CommandClose,
 		Description: "close matching pull requests, optionally deleting their source branches",
 	},
+	{
+		Name:        CommandSchema,
+		Description: "print the JSON Schema of a JSON output document",
+	},
+	{
+		Name:        CommandAudit,
+		Description: "list the mutations recorded in the local audit log",
+	},
+	{
+		Name:        CommandHistory,
+		Description: "show trends across the runs recorded in the local history file",
+	},
 }
 
 // Config holds all configuration for ghprmerge.
@@ -75,6 +96,7 @@ type Config struct {
 	Repos              []string
 	RepoLimit          int
 	JSON               bool
+	Format             string
 	Confirm            bool
 	Verbose            bool
 	NoColor            bool
@@ -87,6 +109,72 @@ type Config struct {
 	Verbosity          string
 	Command            Command
 	Author             string
+	RecreateConflicted bool
+	DependabotIgnore   string
+	Labels             []string
+	ExcludeLabels      []string
+	LabelSkipped       bool
+	CommentOnSkip      bool
+	TUI                bool
+	JSONCasing         string
+	SchemaDocument     string
+	FailOn             []string
+	StateFile          string
+	Resume             bool
+	AuditLog           string
+	AuditStrict        bool
+	AuditFilter        audit.Filter
+	HistoryFile        string
+	NoHistory          bool
+	HistorySince       time.Time
+	CompareReport      string
+	GroupBy            string
+	FromReport         string
+	ReportGroups       []string
+	MinAge             time.Duration
+	MaxAge             time.Duration
+	View               string
+	StaleAfter         time.Duration
+	Superseded         bool
+	Stale              bool
+	InactiveFor        time.Duration
+	FailingFor         time.Duration
+	Conflicted         bool
+	CloseComment       string
+}
+
+// OutputFormat returns the selected output format, honoring --json.
+func (c *Config) OutputFormat() string {
+	if c.JSON {
+		return "json"
+	}
+	if c.Format == "" {
+		return "text"
+	}
+	return c.Format
+}
+
+// FailConditions returns the conditions that make the run exit non-zero:
+// the --fail-on values, the defaults when it is unset, or none for "none".
+func (c *Config) FailConditions() []output.FailCondition {
+	if len(c.FailOn) == 0 {
+		return output.DefaultFailOn
+	}
+	var conditions []output.FailCondition
+	for _, name := range c.FailOn {
+		if condition := output.FailCondition(name); condition != output.FailOnNone {
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions
+}
+
+// ReportStaleAfter returns --stale-after, or the default when it is unset.
+func (c *Config) ReportStaleAfter() time.Duration {
+	if c.StaleAfter == 0 {
+		return output.DefaultStaleAfter
+	}
+	return c.StaleAfter
 }
 
 // IsAnalysisOnly returns true if no mutating subcommand is used.
@@ -96,6 +184,15 @@ func (c *Config) IsAnalysisOnly() bool {
 
 // Validate checks that all required configuration is present.
 func (c *Config) Validate() error {
+	if c.Command == CommandSchema {
+		return c.validateSchema()
+	}
+	if c.Command == CommandAudit {
+		return c.validateAudit()
+	}
+	if c.Command == CommandHistory {
+		return c.validateHistory()
+	}
 	if c.Org == "" {
 		return fmt.Errorf("--org is required (or set GITHUB_ORG environment variable)")
 	}
@@ -105,6 +202,39 @@ func (c *Config) Validate() error {
 	if c.MinMergeDelay < 0 {
 		return fmt.Errorf("--min-merge-delay must be 0 or greater")
 	}
+	if c.Format != "" && !slices.Contains(output.Formats, output.Format(c.Format)) {
+		names := make([]string, len(output.Formats))
+		for i, format := range output.Formats {
+			names[i] = string(format)
+		}
+		return fmt.Errorf("--format must be one of: %s", strings.Join(names, ", "))
+	}
+	if c.Format == "html" && !c.Report {
+		return fmt.Errorf("--format html is only supported by the report command")
+	}
+	if (c.Format == "junit" || c.Format == "ndjson") && c.Report {
+		return fmt.Errorf("--format %s is not supported by the report command", c.Format)
+	}
+	if c.JSON && c.Format != "" && c.Format != "json" {
+		return fmt.Errorf("--json cannot be used with --format %s", c.Format)
+	}
+	if err := validateJSONCasing(c.JSONCasing); err != nil {
+		return err
+	}
+	if c.JSONCasing != "" && !c.Report {
+		return fmt.Errorf("--json-casing can only be used with the report command")
+	}
+	if c.MinAge > 0 && c.MaxAge > 0 && c.MinAge > c.MaxAge {
+		return fmt.Errorf("--min-age cannot be greater than --max-age")
+	}
+	for _, name := range c.FailOn {
+		if !slices.Contains(output.FailConditions, output.FailCondition(name)) {
+			return fmt.Errorf("unknown --fail-on condition %q: must be one of %s", name, output.FailConditionList())
+		}
+		if name == string(output.FailOnNone) && len(c.FailOn) > 1 {
+			return fmt.Errorf("--fail-on none cannot be combined with other conditions")
+		}
+	}
 
 	// Report mode validation
 	if c.Report {
@@ -123,11 +253,44 @@ func (c *Config) Validate() error {
 		if c.Verbosity != "" && c.Verbosity != "brief" && c.Verbosity != "standard" && c.Verbosity != "verbose" {
 			return fmt.Errorf("--verbosity must be one of: brief, standard, verbose")
 		}
+		if c.GroupBy != "" && !slices.Contains(output.ReportGroupings, output.ReportGroupBy(c.GroupBy)) {
+			return fmt.Errorf("--group-by must be one of: %s", reportGroupingList())
+		}
+		if c.View != "" && c.View != string(output.ViewGroups) && c.View != string(output.ViewStale) {
+			return fmt.Errorf("--view must be one of: groups, stale")
+		}
+		if c.View == string(output.ViewStale) {
+			if c.GroupBy != "" {
+				return fmt.Errorf("--group-by cannot be used with --view stale")
+			}
+			if c.CompareReport != "" {
+				return fmt.Errorf("--compare cannot be used with --view stale")
+			}
+		} else if c.StaleAfter > 0 && c.CompareReport == "" {
+			return fmt.Errorf("--stale-after requires --view stale or --compare")
+		}
+		if c.CompareReport != "" {
+			switch c.OutputFormat() {
+			case "text", "json", "markdown":
+			default:
+				return fmt.Errorf("--compare is only supported with text, json, or markdown output")
+			}
+		}
 		return nil
 	}
 
 	// Non-report mode validation
-	if len(c.SourceBranches) == 0 {
+	if len(c.ReportGroups) > 0 && c.FromReport == "" {
+		return fmt.Errorf("--group requires --from-report")
+	}
+	if c.FromReport != "" {
+		if len(c.ReportGroups) == 0 {
+			return fmt.Errorf("--from-report requires at least one --group")
+		}
+		if len(c.SourceBranches) > 0 {
+			return fmt.Errorf("--source-branch cannot be used with --from-report; the report groups select the pull requests")
+		}
+	} else if len(c.SourceBranches) == 0 {
 		if c.Command == CommandNone {
 			return errors.New(formatSubcommandGuidanceError("choose a subcommand or provide --source-branch for analysis-only mode"))
 		}
@@ -139,6 +302,65 @@ func (c *Config) Validate() error {
 	if c.Verbosity != "" {
 		return fmt.Errorf("--verbosity can only be used with the report command")
 	}
+	if c.RecreateConflicted && !c.Rebase && !c.Merge {
+		return fmt.Errorf("--recreate-conflicted requires the rebase or merge command")
+	}
+	if c.DependabotIgnore != "" {
+		if !c.Close {
+			return fmt.Errorf("--dependabot-ignore requires the close command")
+		}
+		if !slices.Contains(gh.IgnoreScopes, c.DependabotIgnore) {
+			return fmt.Errorf("--dependabot-ignore must be one of: %s", strings.Join(gh.IgnoreScopes, ", "))
+		}
+		if c.Superseded {
+			return fmt.Errorf("--dependabot-ignore cannot be used with --superseded; ignoring the old version could also ignore the newer update")
+		}
+	}
+	if c.Stale {
+		if c.Superseded {
+			return fmt.Errorf("--stale cannot be used with --superseded")
+		}
+		if c.InactiveFor == 0 && c.FailingFor == 0 && !c.Conflicted {
+			return fmt.Errorf("--stale requires at least one of --inactive-for, --failing-for, or --conflicted")
+		}
+	} else if c.InactiveFor > 0 || c.FailingFor > 0 || c.Conflicted {
+		return fmt.Errorf("--inactive-for, --failing-for, and --conflicted require --stale")
+	}
+	if c.CloseComment != "" && c.Superseded {
+		return fmt.Errorf("--close-comment cannot be used with --superseded, which posts its own comment")
+	}
+	if !c.Stale && strings.Contains(c.CloseComment, "{reason}") {
+		return fmt.Errorf("--close-comment {reason} requires --stale, which provides the reason")
+	}
+	if c.NoHistory && c.HistoryFile != "" {
+		return fmt.Errorf("--no-history cannot be used with --history-file")
+	}
+	if c.AuditStrict && c.AuditLog == audit.Off {
+		return fmt.Errorf("--audit-strict cannot be used with --audit-log off")
+	}
+	if c.LabelSkipped && !c.Rebase && !c.Merge {
+		return fmt.Errorf("--label-skipped requires the rebase or merge command")
+	}
+	if c.CommentOnSkip && !c.Rebase && !c.Merge {
+		return fmt.Errorf("--comment-on-skip requires the rebase or merge command")
+	}
+	if c.Resume && c.StateFile == "" {
+		return fmt.Errorf("--resume requires --state-file")
+	}
+	if c.TUI {
+		if c.StateFile != "" {
+			return fmt.Errorf("--tui cannot be used with --state-file")
+		}
+		if c.Command == CommandNone {
+			return fmt.Errorf("--tui requires the merge, rebase, or close command")
+		}
+		if c.JSON {
+			return fmt.Errorf("--tui cannot be used with --json")
+		}
+		if c.Confirm {
+			return fmt.Errorf("--tui cannot be used with --confirm; the dashboard asks before each action")
+		}
+	}
 	// --skip-rebase requires merge subcommand
 	if c.SkipRebase && !c.Merge {
 		return fmt.Errorf("--skip-rebase requires the merge command; it allows merging PRs without requiring the branch to be up-to-date")
@@ -189,7 +411,7 @@ func ParseFlags(args []string, version string) (*Config, error) {
 	subCmdIdx := -1
 	for i, arg := range args {
 		switch arg {
-		case "merge", "rebase", "report", "close":
+		case "merge", "rebase", "report", "close", "schema", "audit", "history":
 			command = Command(arg)
 			subCmdIdx = i
 		}
@@ -208,6 +430,7 @@ func ParseFlags(args []string, version string) (*Config, error) {
 	org := os.Getenv("GITHUB_ORG")
 	repoLimit := 0
 	jsonOutput := false
+	format := ""
 	verbose := false
 	noColor := false
 	noProgress := false
@@ -232,6 +455,16 @@ func ParseFlags(args []string, version string) (*Config, error) {
 		return nil, ErrVersion
 	}
 
+	if command == CommandSchema {
+		return parseSchemaFlags(subArgs)
+	}
+	if command == CommandAudit {
+		return parseAuditFlags(subArgs)
+	}
+	if command == CommandHistory {
+		return parseHistoryFlags(subArgs)
+	}
+
 	if command == CommandNone {
 		remainingArgs := globalFS.Args()
 		if len(remainingArgs) > 0 {
@@ -249,6 +482,33 @@ func ParseFlags(args []string, version string) (*Config, error) {
 	var verbosity string
 	var repos StringSliceFlag
 	var deleteSourceBranch bool
+	var recreateConflicted bool
+	var dependabotIgnore string
+	var labels StringSliceFlag
+	var excludeLabels StringSliceFlag
+	var labelSkipped bool
+	var commentOnSkip bool
+	var tui bool
+	var jsonCasing string
+	var failOnStr string
+	var stateFile string
+	var resume bool
+	var auditLog string
+	var auditStrict bool
+	var historyFile string
+	var noHistory bool
+	var compareReport string
+	var groupBy string
+	var fromReport string
+	var reportGroups StringSliceFlag
+	var minAge AgeFlag
+	var maxAge AgeFlag
+	var view string
+	var staleAfter AgeFlag
+	var superseded bool
+	var stale, conflicted bool
+	var inactiveFor, failingFor AgeFlag
+	var closeComment string
 
 	if command != CommandNone {
 		subFS := flag.NewFlagSet(string(command), flag.ContinueOnError)
@@ -259,9 +519,14 @@ func ParseFlags(args []string, version string) (*Config, error) {
 		subFS.Var(&repos, "repo", "Exact repository name in the organization to scan (may be repeated)")
 		subFS.IntVar(&repoLimit, "repo-limit", repoLimit, "Maximum number of repositories to process (0 = unlimited)")
 		subFS.BoolVar(&jsonOutput, "json", jsonOutput, "Output structured JSON instead of human-readable text")
+		subFS.StringVar(&format, "format", format, "Output format: text, json, markdown, html (report only), csv, tsv, junit, or ndjson (not report)")
 		subFS.BoolVar(&noColor, "no-color", noColor, "Disable colored output")
 		subFS.BoolVar(&noProgress, "no-progress", noProgress, "Suppress progress bar output (useful for scripting, CI, and non-TTY environments)")
 		subFS.StringVar(&author, "author", author, "Filter pull requests by author login (e.g. dependabot[bot] or a GitHub username)")
+		subFS.Var(&labels, "label", "Only include pull requests that have this label (repeatable; all must match)")
+		subFS.Var(&excludeLabels, "exclude-label", "Exclude pull requests that have this label (repeatable)")
+		subFS.Var(&minAge, "min-age", "Only include pull requests opened at least this long ago, e.g. 7d or 12h")
+		subFS.Var(&maxAge, "max-age", "Only include pull requests opened at most this long ago, e.g. 30d")
 
 		switch command {
 		case CommandMerge:
@@ -271,18 +536,61 @@ func ParseFlags(args []string, version string) (*Config, error) {
 			}
 			subFS.BoolVar(&verbose, "verbose", verbose, "Show all repositories including those with no matching pull requests")
 			subFS.Var(&sourceBranches, "source-branch", "Branch name pattern to match pull request head branches (repeatable)")
+			subFS.StringVar(&fromReport, "from-report", "", "Act on pull requests from a report saved with --json instead of matching --source-branch")
+			subFS.Var(&reportGroups, "group", "Report group to act on with --from-report (repeatable)")
 			subFS.BoolVar(&skipRebase, "skip-rebase", false, "Skip rebase check and merge PRs that are behind")
 			subFS.BoolVar(&confirm, "confirm", false, "Scan all repos first, then prompt for confirmation")
+			subFS.BoolVar(&tui, "tui", false, "Scan all repos first, then browse and act on pull requests in a full-screen dashboard")
 			subFS.IntVar(&minMergeDelay, "min-merge-delay", defaultMinMergeDelay, "Minimum seconds between merge requests (0 = no delay)")
+			subFS.BoolVar(&recreateConflicted, "recreate-conflicted", false, "Ask the owning bot to recreate conflicted pull requests instead of skipping them")
+			subFS.BoolVar(&labelSkipped, "label-skipped", false, "Label skipped pull requests with their skip reason (e.g. ghprmerge:conflict)")
+			subFS.BoolVar(&commentOnSkip, "comment-on-skip", false, "Keep a status comment on skipped or failed pull requests explaining why")
+			subFS.StringVar(&failOnStr, "fail-on", "", "Comma-separated conditions that make the run exit non-zero (default action-failed,api-error)")
+			subFS.StringVar(&stateFile, "state-file", "", "Checkpoint progress to this file so an interrupted run can be resumed")
+			subFS.StringVar(&auditLog, "audit-log", "", "Append every mutation to this JSONL audit log, or off (default GHPRMERGE_AUDIT_LOG or the user config directory)")
+			subFS.BoolVar(&auditStrict, "audit-strict", false, "Stop when the audit log cannot be opened, and exit non-zero when an entry cannot be written")
+			subFS.StringVar(&historyFile, "history-file", "", "Append the run result to this JSONL history file, or off (default GHPRMERGE_HISTORY_FILE or the user config directory)")
+			subFS.BoolVar(&noHistory, "no-history", false, "Do not record this run in the history file")
+			subFS.BoolVar(&resume, "resume", false, "Resume the run recorded in --state-file, skipping repositories it completed")
 		case CommandRebase:
 			subFS.BoolVar(&verbose, "verbose", verbose, "Show all repositories including those with no matching pull requests")
 			subFS.Var(&sourceBranches, "source-branch", "Branch name pattern to match pull request head branches (repeatable)")
+			subFS.StringVar(&fromReport, "from-report", "", "Act on pull requests from a report saved with --json instead of matching --source-branch")
+			subFS.Var(&reportGroups, "group", "Report group to act on with --from-report (repeatable)")
+			subFS.BoolVar(&recreateConflicted, "recreate-conflicted", false, "Ask the owning bot to recreate conflicted pull requests instead of skipping them")
+			subFS.BoolVar(&labelSkipped, "label-skipped", false, "Label skipped pull requests with their skip reason (e.g. ghprmerge:conflict)")
+			subFS.BoolVar(&commentOnSkip, "comment-on-skip", false, "Keep a status comment on skipped or failed pull requests explaining why")
 			subFS.BoolVar(&confirm, "confirm", false, "Scan all repos first, then prompt for confirmation")
+			subFS.BoolVar(&tui, "tui", false, "Scan all repos first, then browse and act on pull requests in a full-screen dashboard")
+			subFS.StringVar(&failOnStr, "fail-on", "", "Comma-separated conditions that make the run exit non-zero (default action-failed,api-error)")
+			subFS.StringVar(&stateFile, "state-file", "", "Checkpoint progress to this file so an interrupted run can be resumed")
+			subFS.StringVar(&auditLog, "audit-log", "", "Append every mutation to this JSONL audit log, or off (default GHPRMERGE_AUDIT_LOG or the user config directory)")
+			subFS.BoolVar(&auditStrict, "audit-strict", false, "Stop when the audit log cannot be opened, and exit non-zero when an entry cannot be written")
+			subFS.StringVar(&historyFile, "history-file", "", "Append the run result to this JSONL history file, or off (default GHPRMERGE_HISTORY_FILE or the user config directory)")
+			subFS.BoolVar(&noHistory, "no-history", false, "Do not record this run in the history file")
+			subFS.BoolVar(&resume, "resume", false, "Resume the run recorded in --state-file, skipping repositories it completed")
 		case CommandClose:
 			subFS.BoolVar(&verbose, "verbose", verbose, "Show all repositories including those with no matching pull requests")
 			subFS.Var(&sourceBranches, "source-branch", "Branch name pattern to match pull request head branches (repeatable)")
+			subFS.StringVar(&fromReport, "from-report", "", "Act on pull requests from a report saved with --json instead of matching --source-branch")
+			subFS.Var(&reportGroups, "group", "Report group to act on with --from-report (repeatable)")
 			subFS.BoolVar(&deleteSourceBranch, "delete-source-branch", false, "Delete the pull request source branch after closing")
+			subFS.StringVar(&dependabotIgnore, "dependabot-ignore", "", "Tell Dependabot to ignore closed updates: major-version, minor-version, or dependency")
+			subFS.BoolVar(&superseded, "superseded", false, "Only close pull requests superseded by a newer version of the same package in the repository")
+			subFS.BoolVar(&stale, "stale", false, "Only close pull requests that meet a --inactive-for, --failing-for, or --conflicted criterion")
+			subFS.Var(&inactiveFor, "inactive-for", "With --stale, close pull requests not updated for at least this long, e.g. 30d")
+			subFS.Var(&failingFor, "failing-for", "With --stale, close pull requests whose checks have been failing for at least this long, e.g. 14d, counted from the first failure on the head commit")
+			subFS.BoolVar(&conflicted, "conflicted", false, "With --stale, close pull requests that have merge conflicts")
+			subFS.StringVar(&closeComment, "close-comment", "", "Comment to post before closing each pull request; {reason} is replaced with the --stale reason")
 			subFS.BoolVar(&confirm, "confirm", false, "Scan all repos first, then prompt for confirmation")
+			subFS.BoolVar(&tui, "tui", false, "Scan all repos first, then browse and act on pull requests in a full-screen dashboard")
+			subFS.StringVar(&failOnStr, "fail-on", "", "Comma-separated conditions that make the run exit non-zero (default action-failed,api-error)")
+			subFS.StringVar(&stateFile, "state-file", "", "Checkpoint progress to this file so an interrupted run can be resumed")
+			subFS.StringVar(&auditLog, "audit-log", "", "Append every mutation to this JSONL audit log, or off (default GHPRMERGE_AUDIT_LOG or the user config directory)")
+			subFS.BoolVar(&auditStrict, "audit-strict", false, "Stop when the audit log cannot be opened, and exit non-zero when an entry cannot be written")
+			subFS.StringVar(&historyFile, "history-file", "", "Append the run result to this JSONL history file, or off (default GHPRMERGE_HISTORY_FILE or the user config directory)")
+			subFS.BoolVar(&noHistory, "no-history", false, "Do not record this run in the history file")
+			subFS.BoolVar(&resume, "resume", false, "Resume the run recorded in --state-file, skipping repositories it completed")
 		case CommandReport:
 			subFS.String("source-branch-prefix", "", "Comma-separated list of branch prefixes to include in report")
 			defaultMinGroupSize := 2
@@ -294,6 +602,11 @@ func ParseFlags(args []string, version string) (*Config, error) {
 			}
 			subFS.Int("min-group-size", defaultMinGroupSize, "Minimum number of PRs in a group to include in report")
 			subFS.String("verbosity", "", "Report output verbosity: brief, standard, or verbose")
+			subFS.StringVar(&jsonCasing, "json-casing", "", "Report JSON key casing: camel (default) or snake")
+			subFS.StringVar(&compareReport, "compare", "", "Compare against a report saved with --json and show what changed")
+			subFS.StringVar(&groupBy, "group-by", "", "Group pull requests by branch (default), package, package-version, ecosystem, author, or status")
+			subFS.StringVar(&view, "view", "", "Report view: groups (default) or stale")
+			subFS.Var(&staleAfter, "stale-after", "With --view stale or --compare, the age at which a pull request is stale (default 14d)")
 		}
 
 		if err := subFS.Parse(subArgs); err != nil {
@@ -329,6 +642,13 @@ func ParseFlags(args []string, version string) (*Config, error) {
 		}
 	}
 
+	var failOn []string
+	for name := range strings.SplitSeq(failOnStr, ",") {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			failOn = append(failOn, trimmed)
+		}
+	}
+
 	// Resolve authentication token
 	token := resolveToken()
 
@@ -349,7 +669,8 @@ func ParseFlags(args []string, version string) (*Config, error) {
 		SkipRebase:         skipRebase,
 		Repos:              repos,
 		RepoLimit:          repoLimit,
-		JSON:               jsonOutput,
+		JSON:               jsonOutput || format == "json",
+		Format:             format,
 		Confirm:            confirm,
 		Verbose:            verbose,
 		NoColor:            noColor,
@@ -362,9 +683,46 @@ func ParseFlags(args []string, version string) (*Config, error) {
 		Verbosity:          verbosity,
 		Command:            command,
 		Author:             author,
+		RecreateConflicted: recreateConflicted,
+		DependabotIgnore:   dependabotIgnore,
+		Labels:             labels,
+		ExcludeLabels:      excludeLabels,
+		LabelSkipped:       labelSkipped,
+		CommentOnSkip:      commentOnSkip,
+		TUI:                tui,
+		JSONCasing:         jsonCasing,
+		FailOn:             failOn,
+		StateFile:          stateFile,
+		Resume:             resume,
+		AuditLog:           auditLog,
+		AuditStrict:        auditStrict,
+		HistoryFile:        historyFile,
+		NoHistory:          noHistory,
+		CompareReport:      compareReport,
+		GroupBy:            groupBy,
+		FromReport:         fromReport,
+		ReportGroups:       reportGroups,
+		MinAge:             time.Duration(minAge),
+		MaxAge:             time.Duration(maxAge),
+		View:               view,
+		StaleAfter:         time.Duration(staleAfter),
+		Superseded:         superseded,
+		Stale:              stale,
+		InactiveFor:        time.Duration(inactiveFor),
+		FailingFor:         time.Duration(failingFor),
+		Conflicted:         conflicted,
+		CloseComment:       closeComment,
 	}, nil
 }
 
+func reportGroupingList() string {
+	names := make([]string, len(output.ReportGroupings))
+	for i, groupBy := range output.ReportGroupings {
+		names[i] = string(groupBy)
+	}
+	return strings.Join(names, ", ")
+}
+
 func envNonNegativeInt(name string) (int, error) {
 	value := os.Getenv(name)
 	if value == "" {
@@ -444,9 +802,14 @@ func printSharedCommandFlags(w io.Writer) {
 func printGlobalFlags(w io.Writer) {
 	fmt.Fprintln(w, "\nFiltering and execution flags:")
 	fmt.Fprintln(w, "  --author <login>           Only include pull requests opened by this GitHub login.")
+	fmt.Fprintln(w, "  --label <name>             Only include pull requests with this label; may be repeated (all must match).")
+	fmt.Fprintln(w, "  --exclude-label <name>     Exclude pull requests with this label; may be repeated.")
+	fmt.Fprintln(w, "  --min-age <age>            Only include pull requests opened at least this long ago, e.g. 7d or 12h.")
+	fmt.Fprintln(w, "  --max-age <age>            Only include pull requests opened at most this long ago, e.g. 30d.")
 	fmt.Fprintln(w, "  --repo-limit <n>           Process at most n repositories (0 means unlimited).")
 	fmt.Fprintln(w, "\nOutput flags:")
 	fmt.Fprintln(w, "  --json                     Emit structured JSON instead of human-readable output.")
+	fmt.Fprintln(w, "  --format <format>          Output format: text (default), json, markdown, html (report only), csv, tsv, junit, or ndjson.")
 	fmt.Fprintln(w, "  --no-color                 Disable ANSI color output.")
 	fmt.Fprintln(w, "  --no-progress              Suppress progress-bar output for CI or scripts.")
 	fmt.Fprintln(w, "  --version                  Print version information and exit.")
@@ -456,26 +819,75 @@ func printCommandFlags(w io.Writer, command Command) {
 	switch command {
 	case CommandMerge:
 		fmt.Fprintln(w, "\nMerge flags:")
-		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required and may be repeated.")
+		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required unless --from-report is used, and may be repeated.")
+		fmt.Fprintln(w, "  --from-report <report.json>  Act on the pull requests of a saved report instead of --source-branch.")
+		fmt.Fprintln(w, "  --group <name>             Report group to act on with --from-report; may be repeated.")
 		fmt.Fprintln(w, "  --skip-rebase              Allow merge attempts when a branch is behind its default branch.")
 		fmt.Fprintln(w, "  --min-merge-delay <secs>  Minimum seconds between merge requests (0 means no delay).")
+		fmt.Fprintln(w, "  --recreate-conflicted      Ask Dependabot or Renovate to recreate conflicted pull requests.")
+		fmt.Fprintln(w, "  --label-skipped            Label skipped pull requests with their skip reason, e.g. ghprmerge:conflict.")
+		fmt.Fprintln(w, "  --comment-on-skip          Keep one status comment on skipped or failed pull requests explaining why.")
 		fmt.Fprintln(w, "  --confirm                  Scan first, then prompt before merging candidates.")
+		fmt.Fprintln(w, "  --fail-on <conditions>     Exit non-zero on: action-failed, merge-failed, rebase-failed, close-failed, api-error, nothing-to-do, or none.")
+		fmt.Fprintln(w, "  --audit-log <path>         Append every mutation to this JSONL audit log, or off to disable it.")
+		fmt.Fprintln(w, "  --audit-strict             Stop when the audit log cannot be opened instead of warning; exit non-zero if an entry is lost.")
+		fmt.Fprintln(w, "  --history-file <path>      Append the run result to this JSONL history file, or off to disable it.")
+		fmt.Fprintln(w, "  --no-history               Do not record this run in the history file.")
+		fmt.Fprintln(w, "  --state-file <path>        Checkpoint progress to this file as repositories and pull requests finish.")
+		fmt.Fprintln(w, "  --resume                   Resume the run in --state-file: skip completed repositories, re-verify in-flight pull requests.")
+		fmt.Fprintln(w, "  --tui                      Scan first, then browse and act on pull requests in a full-screen dashboard.")
 		fmt.Fprintln(w, "  --verbose                  Show repositories with no matching pull requests as they are scanned.")
 	case CommandRebase:
 		fmt.Fprintln(w, "\nRebase flags:")
-		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required and may be repeated.")
+		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required unless --from-report is used, and may be repeated.")
+		fmt.Fprintln(w, "  --from-report <report.json>  Act on the pull requests of a saved report instead of --source-branch.")
+		fmt.Fprintln(w, "  --group <name>             Report group to act on with --from-report; may be repeated.")
+		fmt.Fprintln(w, "  --recreate-conflicted      Ask Dependabot or Renovate to recreate conflicted pull requests.")
+		fmt.Fprintln(w, "  --label-skipped            Label skipped pull requests with their skip reason, e.g. ghprmerge:conflict.")
+		fmt.Fprintln(w, "  --comment-on-skip          Keep one status comment on skipped or failed pull requests explaining why.")
 		fmt.Fprintln(w, "  --confirm                  Scan first, then prompt before rebasing candidates.")
+		fmt.Fprintln(w, "  --fail-on <conditions>     Exit non-zero on: action-failed, merge-failed, rebase-failed, close-failed, api-error, nothing-to-do, or none.")
+		fmt.Fprintln(w, "  --audit-log <path>         Append every mutation to this JSONL audit log, or off to disable it.")
+		fmt.Fprintln(w, "  --audit-strict             Stop when the audit log cannot be opened instead of warning; exit non-zero if an entry is lost.")
+		fmt.Fprintln(w, "  --history-file <path>      Append the run result to this JSONL history file, or off to disable it.")
+		fmt.Fprintln(w, "  --no-history               Do not record this run in the history file.")
+		fmt.Fprintln(w, "  --state-file <path>        Checkpoint progress to this file as repositories and pull requests finish.")
+		fmt.Fprintln(w, "  --resume                   Resume the run in --state-file: skip completed repositories, re-verify in-flight pull requests.")
+		fmt.Fprintln(w, "  --tui                      Scan first, then browse and act on pull requests in a full-screen dashboard.")
 		fmt.Fprintln(w, "  --verbose                  Show repositories with no matching pull requests as they are scanned.")
 	case CommandReport:
 		fmt.Fprintln(w, "\nReport flags:")
 		fmt.Fprintln(w, "  --source-branch-prefix <prefixes>  Comma-separated head-branch prefixes to include.")
 		fmt.Fprintln(w, "  --min-group-size <n>               Include only groups with at least n pull requests (default 2).")
 		fmt.Fprintln(w, "  --verbosity <level>                 Text detail: brief, standard, or verbose.")
+		fmt.Fprintln(w, "  --json-casing <casing>              JSON key casing: camel (default) or snake, matching the run output.")
+		fmt.Fprintln(w, "  --group-by <field>                  Group by branch (default), package, package-version, ecosystem, author, or status.")
+		fmt.Fprintln(w, "  --compare <report.json>             Show new, grown, shrunk, and removed groups, status changes, and newly stale PRs since a saved report.")
+		fmt.Fprintln(w, "  --view <view>                       groups (default), or stale to list PRs open or failing longer than --stale-after, oldest first.")
+		fmt.Fprintln(w, "  --stale-after <age>                 Age at which --view stale lists a pull request and --compare reports it as newly stale (default 14d).")
 	case CommandClose:
 		fmt.Fprintln(w, "\nClose flags:")
-		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required and may be repeated.")
+		fmt.Fprintln(w, "  --source-branch <pattern>  Pull request head-branch prefix to match; required unless --from-report is used, and may be repeated.")
+		fmt.Fprintln(w, "  --from-report <report.json>  Act on the pull requests of a saved report instead of --source-branch.")
+		fmt.Fprintln(w, "  --group <name>             Report group to act on with --from-report; may be repeated.")
 		fmt.Fprintln(w, "  --delete-source-branch     Delete each source branch after its pull request is closed.")
+		fmt.Fprintln(w, "  --dependabot-ignore <scope>  Before closing Dependabot PRs, post @dependabot ignore for major-version, minor-version, or dependency.")
+		fmt.Fprintln(w, "  --superseded               Only close PRs superseded by a newer update of the same package, with a comment naming it.")
+		fmt.Fprintln(w, "  --stale                    Only close PRs matching --inactive-for, --failing-for, or --conflicted, reporting why.")
+		fmt.Fprintln(w, "  --inactive-for <age>       With --stale, close PRs not updated for at least this long, e.g. 30d.")
+		fmt.Fprintln(w, "  --failing-for <age>        With --stale, close PRs whose checks have been failing for at least this long,")
+		fmt.Fprintln(w, "                             counted from the first failure on the head commit; re-runs do not reset it.")
+		fmt.Fprintln(w, "  --conflicted               With --stale, close PRs that have merge conflicts.")
+		fmt.Fprintln(w, "  --close-comment <text>     Comment to post before closing; {reason} is replaced with the --stale reason.")
 		fmt.Fprintln(w, "  --confirm                  Scan first, then prompt before closing candidates.")
+		fmt.Fprintln(w, "  --fail-on <conditions>     Exit non-zero on: action-failed, merge-failed, rebase-failed, close-failed, api-error, nothing-to-do, or none.")
+		fmt.Fprintln(w, "  --audit-log <path>         Append every mutation to this JSONL audit log, or off to disable it.")
+		fmt.Fprintln(w, "  --audit-strict             Stop when the audit log cannot be opened instead of warning; exit non-zero if an entry is lost.")
+		fmt.Fprintln(w, "  --history-file <path>      Append the run result to this JSONL history file, or off to disable it.")
+		fmt.Fprintln(w, "  --no-history               Do not record this run in the history file.")
+		fmt.Fprintln(w, "  --state-file <path>        Checkpoint progress to this file as repositories and pull requests finish.")
+		fmt.Fprintln(w, "  --resume                   Resume the run in --state-file: skip completed repositories, re-verify in-flight pull requests.")
+		fmt.Fprintln(w, "  --tui                      Scan first, then browse and act on pull requests in a full-screen dashboard.")
 		fmt.Fprintln(w, "  --verbose                  Show repositories with no matching pull requests as they are scanned.")
 	}
 }
@@ -487,6 +899,8 @@ func printEnvironmentVariables(w io.Writer) {
 	fmt.Fprintln(w, "  GHPRMERGE_AUTHOR           Default GitHub login for --author.")
 	fmt.Fprintln(w, "  GHPRMERGE_MIN_GROUP_SIZE   Default --min-group-size value for report.")
 	fmt.Fprintln(w, "  GHPRMERGE_MIN_MERGE_DELAY  Default --min-merge-delay value for merge (seconds).")
+	fmt.Fprintln(w, "  GHPRMERGE_AUDIT_LOG        Default --audit-log path for merge, rebase, close, and audit.")
+	fmt.Fprintln(w, "  GHPRMERGE_HISTORY_FILE     Default --history-file path for merge, rebase, close, and history.")
 }
 
 func formatSubcommandGuidanceError(summary string) string {
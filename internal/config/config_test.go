@@ -5,8 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/audit"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
 )
 
 func TestRootHelpDocumentsCommandsFlagsAndEnvironment(t *testing.T) {
@@ -14,9 +20,10 @@ func TestRootHelpDocumentsCommandsFlagsAndEnvironment(t *testing.T) {
 	printGlobalUsage(&output, nil)
 
 	for _, expected := range []string{
-		"merge  merge ready pull requests",
-		"rebase update pull request branches",
-		"report scan open pull requests",
+		"merge   merge ready pull requests",
+		"rebase  update pull request branches",
+		"report  scan open pull requests",
+		"history show trends",
 		"Required setup:",
 		"--org <organization>",
 		"--repo <repository>",
@@ -140,6 +147,155 @@ func TestParseFlagsCloseCommand(t *testing.T) {
 	}
 }
 
+func TestParseFlagsDependabotCommands(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"rebase", "--source-branch", "dependabot/", "--recreate-conflicted"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !cfg.RecreateConflicted {
+		t.Error("RecreateConflicted = false, want true")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("rebase config Validate() error = %v", err)
+	}
+
+	cfg, err = ParseFlags([]string{"close", "--source-branch", "dependabot/", "--dependabot-ignore", "major-version"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if cfg.DependabotIgnore != "major-version" {
+		t.Errorf("DependabotIgnore = %q, want major-version", cfg.DependabotIgnore)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("close config Validate() error = %v", err)
+	}
+
+	cfg, err = ParseFlags([]string{"merge", "--source-branch", "dependabot/", "--recreate-conflicted"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !cfg.RecreateConflicted || cfg.Validate() != nil {
+		t.Errorf("merge --recreate-conflicted = (%v, %v), want accepted", cfg.RecreateConflicted, cfg.Validate())
+	}
+
+	if _, err := ParseFlags([]string{"close", "--source-branch", "dependabot/", "--recreate-conflicted"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --recreate-conflicted on close, want error")
+	}
+}
+
+func TestParseFlagsLabels(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--label", "dependencies", "--label", "automerge", "--exclude-label", "on-hold", "--label-skipped", "--comment-on-skip"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Labels, []string{"dependencies", "automerge"}) {
+		t.Errorf("Labels = %v, want [dependencies automerge]", cfg.Labels)
+	}
+	if !reflect.DeepEqual(cfg.ExcludeLabels, []string{"on-hold"}) {
+		t.Errorf("ExcludeLabels = %v, want [on-hold]", cfg.ExcludeLabels)
+	}
+	if !cfg.LabelSkipped {
+		t.Error("LabelSkipped = false, want true")
+	}
+	if !cfg.CommentOnSkip {
+		t.Error("CommentOnSkip = false, want true")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if _, err := ParseFlags([]string{"report", "--label-skipped"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --label-skipped on report, want error")
+	}
+}
+
+func TestParseFlagsTUI(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--tui"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if !cfg.TUI {
+		t.Error("TUI = false, want true")
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"merge", "--source-branch", "deps/", "--tui", "--json"},
+		{"merge", "--source-branch", "deps/", "--tui", "--confirm"},
+	} {
+		cfg, err := ParseFlags(args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", args, err)
+		}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() accepted %v, want error", args)
+		}
+	}
+
+	if _, err := ParseFlags([]string{"report", "--tui"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --tui on report, want error")
+	}
+}
+
+func TestParseFlagsFormat(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{args: []string{"report"}, want: "text"},
+		{args: []string{"report", "--json"}, want: "json"},
+		{args: []string{"report", "--format", "json"}, want: "json"},
+		{args: []string{"report", "--format", "markdown"}, want: "markdown"},
+		{args: []string{"merge", "--source-branch", "deps/", "--format", "markdown"}, want: "markdown"},
+		{args: []string{"report", "--format", "json", "--json"}, want: "json"},
+		{args: []string{"report", "--format", "markdown", "--json"}, wantErr: true},
+		{args: []string{"report", "--format", "yaml"}, wantErr: true},
+		{args: []string{"report", "--format", "html"}, want: "html"},
+		{args: []string{"merge", "--source-branch", "deps/", "--format", "html"}, wantErr: true},
+		{args: []string{"merge", "--source-branch", "deps/", "--format", "csv"}, want: "csv"},
+		{args: []string{"report", "--format", "tsv"}, want: "tsv"},
+		{args: []string{"merge", "--source-branch", "deps/", "--format", "junit"}, want: "junit"},
+		{args: []string{"report", "--format", "junit"}, wantErr: true},
+		{args: []string{"rebase", "--source-branch", "deps/", "--format", "ndjson"}, want: "ndjson"},
+		{args: []string{"report", "--format", "ndjson"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		err = cfg.Validate()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Validate() accepted %v, want error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Validate(%v) error = %v", tt.args, err)
+		}
+		if got := cfg.OutputFormat(); got != tt.want {
+			t.Errorf("OutputFormat() for %v = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
 func TestParseFlags(t *testing.T) {
 	origToken := os.Getenv("GITHUB_TOKEN")
 	origOrg := os.Getenv("GITHUB_ORG")
@@ -640,6 +796,46 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "--verbosity can only be used with the report command",
 		},
+		{
+			name: "recreate-conflicted requires rebase or merge command",
+			config: Config{
+				Org:                "myorg",
+				SourceBranches:     []string{"dependabot/"},
+				SourceBranch:       "dependabot/",
+				Token:              "test-token",
+				Close:              true,
+				Command:            CommandClose,
+				RecreateConflicted: true,
+			},
+			wantErr: true,
+			errMsg:  "--recreate-conflicted requires the rebase or merge command",
+		},
+		{
+			name: "dependabot-ignore requires close command",
+			config: Config{
+				Org:              "myorg",
+				SourceBranches:   []string{"dependabot/"},
+				SourceBranch:     "dependabot/",
+				Token:            "test-token",
+				DependabotIgnore: "dependency",
+			},
+			wantErr: true,
+			errMsg:  "--dependabot-ignore requires the close command",
+		},
+		{
+			name: "dependabot-ignore rejects unknown scope",
+			config: Config{
+				Org:              "myorg",
+				SourceBranches:   []string{"dependabot/"},
+				SourceBranch:     "dependabot/",
+				Token:            "test-token",
+				Close:            true,
+				Command:          CommandClose,
+				DependabotIgnore: "patch-version",
+			},
+			wantErr: true,
+			errMsg:  "--dependabot-ignore must be one of",
+		},
 	}
 
 	for _, tt := range tests {
@@ -969,3 +1165,551 @@ func TestBuildVersionOutputNoVPrefixForDev(t *testing.T) {
 		t.Fatalf("unexpected version output: got %q, want %q", got, want)
 	}
 }
+
+func TestParseFlagsSchema(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_ORG", "")
+
+	tests := []struct {
+		args     []string
+		document string
+		casing   string
+		wantErr  bool
+	}{
+		{args: []string{"schema", "run"}, document: "run"},
+		{args: []string{"schema", "report", "--json-casing", "snake"}, document: "report", casing: "snake"},
+		{args: []string{"schema", "--json-casing", "camel", "event"}, document: "event", casing: "camel"},
+		{args: []string{"schema"}, wantErr: true},
+		{args: []string{"schema", "summary"}, wantErr: true},
+		{args: []string{"schema", "report", "--json-casing", "kebab"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		err = cfg.Validate()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Validate() accepted %v, want error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Validate(%v) error = %v; schema needs no org or token", tt.args, err)
+		}
+		if cfg.Command != CommandSchema || cfg.SchemaDocument != tt.document || cfg.JSONCasing != tt.casing {
+			t.Errorf("ParseFlags(%v) = command %q, document %q, casing %q", tt.args, cfg.Command, cfg.SchemaDocument, cfg.JSONCasing)
+		}
+	}
+
+	if _, err := ParseFlags([]string{"schema", "run", "event"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted two schema documents")
+	}
+}
+
+func TestParseFlagsJSONCasing(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"report", "--json", "--json-casing", "snake"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if cfg.JSONCasing != "snake" {
+		t.Errorf("JSONCasing = %q, want snake", cfg.JSONCasing)
+	}
+
+	cfg, err = ParseFlags([]string{"report", "--json-casing", "upper"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --json-casing upper")
+	}
+
+	if _, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--json-casing", "snake"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --json-casing on merge")
+	}
+}
+
+func TestParseFlagsCompare(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	for _, args := range [][]string{
+		{"report", "--compare", "last-week.json"},
+		{"report", "--compare", "last-week.json", "--json"},
+		{"report", "--compare", "last-week.json", "--format", "markdown"},
+		{"report", "--compare", "last-week.json", "--stale-after", "30d"},
+	} {
+		cfg, err := ParseFlags(args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", args, err)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate(%v) error = %v", args, err)
+		}
+		if cfg.CompareReport != "last-week.json" {
+			t.Errorf("CompareReport = %q", cfg.CompareReport)
+		}
+	}
+
+	cfg, err := ParseFlags([]string{"report", "--compare", "last-week.json", "--format", "csv"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --compare with --format csv")
+	}
+	if _, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--compare", "x.json"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --compare on merge")
+	}
+}
+
+func TestParseFlagsGroupBy(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"report", "--group-by", "package-version"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if cfg.GroupBy != "package-version" {
+		t.Errorf("GroupBy = %q, want package-version", cfg.GroupBy)
+	}
+
+	cfg, err = ParseFlags([]string{"report", "--group-by", "repository"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --group-by repository")
+	}
+	if _, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--group-by", "package"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --group-by on merge")
+	}
+}
+
+func TestParseFlagsFailOn(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	tests := []struct {
+		args    []string
+		want    []output.FailCondition
+		wantErr bool
+	}{
+		{args: []string{"merge", "--source-branch", "deps/"}, want: output.DefaultFailOn},
+		{args: []string{"merge", "--source-branch", "deps/", "--fail-on", "merge-failed, nothing-to-do"}, want: []output.FailCondition{output.FailMergeFailed, output.FailNothingToDo}},
+		{args: []string{"close", "--source-branch", "deps/", "--fail-on", "none"}, want: nil},
+		{args: []string{"rebase", "--source-branch", "deps/", "--fail-on", "api-error,bogus"}, wantErr: true},
+		{args: []string{"merge", "--source-branch", "deps/", "--fail-on", "none,api-error"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		err = cfg.Validate()
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Validate() accepted %v, want error", tt.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Validate(%v) error = %v", tt.args, err)
+		}
+		if got := cfg.FailConditions(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FailConditions() for %v = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+
+	if _, err := ParseFlags([]string{"report", "--fail-on", "api-error"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --fail-on on report")
+	}
+}
+
+func TestParseFlagsStateFile(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{args: []string{"merge", "--source-branch", "deps/", "--state-file", "sweep.json"}},
+		{args: []string{"close", "--source-branch", "deps/", "--state-file", "sweep.json", "--resume"}},
+		{args: []string{"rebase", "--source-branch", "deps/", "--resume"}, wantErr: true},
+		{args: []string{"merge", "--source-branch", "deps/", "--state-file", "sweep.json", "--tui"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		err = cfg.Validate()
+		if tt.wantErr != (err != nil) {
+			t.Errorf("Validate(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+
+	if _, err := ParseFlags([]string{"report", "--state-file", "sweep.json"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --state-file on report")
+	}
+}
+
+func TestParseFlagsAudit(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_ORG", "")
+	t.Setenv("GHPRMERGE_AUDIT_LOG", "/tmp/env-audit.jsonl")
+
+	cfg, err := ParseFlags([]string{"audit", "--repo", "api", "--since", "2026-03-01", "--until", "2026-03-31", "--action", "merge, close", "--json"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.Command != CommandAudit || !cfg.JSON || cfg.AuditFilter.Repository != "api" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.AuditFilter.Actions, []audit.Action{audit.ActionMerge, audit.ActionClose}) {
+		t.Errorf("Actions = %v", cfg.AuditFilter.Actions)
+	}
+	if got := cfg.AuditFilter.Until.Sub(cfg.AuditFilter.Since); got != 31*24*time.Hour {
+		t.Errorf("date range = %v, want all of March", got)
+	}
+	if got, err := cfg.AuditLogPath(); err != nil || got != "/tmp/env-audit.jsonl" {
+		t.Errorf("AuditLogPath() = %q, %v, want the GHPRMERGE_AUDIT_LOG value", got, err)
+	}
+
+	invalid := [][]string{
+		{"audit", "--action", "rebase"},
+		{"audit", "--since", "2026-04-01", "--until", "2026-03-01"},
+		{"audit", "--format", "csv"},
+		{"audit", "--audit-log", "off"},
+	}
+	for _, args := range invalid {
+		cfg, err := ParseFlags(args, "test")
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err == nil {
+			t.Errorf("accepted %v, want error", args)
+		}
+	}
+	if _, err := ParseFlags([]string{"audit", "--since", "last week"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted an invalid --since")
+	}
+}
+
+func TestParseFlagsAuditLog(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"merge", "--source-branch", "deps/", "--audit-log", "off"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.AuditLogPath(); err != nil || got != "" {
+		t.Errorf("AuditLogPath() = %q, %v, want disabled", got, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	cfg, err = ParseFlags([]string{"close", "--source-branch", "deps/", "--audit-log", "ops/audit.jsonl", "--audit-strict"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.AuditLogPath(); err != nil || got != "ops/audit.jsonl" || !cfg.AuditStrict {
+		t.Errorf("AuditLogPath() = %q, %v, strict %v, want ops/audit.jsonl and strict", got, err, cfg.AuditStrict)
+	}
+	if _, err := ParseFlags([]string{"report", "--audit-log", "x"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --audit-log on report")
+	}
+	cfg, err = ParseFlags([]string{"merge", "--source-branch", "deps/", "--audit-log", "off", "--audit-strict"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --audit-strict with --audit-log off")
+	}
+
+	// The environment can turn the log off too.
+	t.Setenv("GHPRMERGE_AUDIT_LOG", "off")
+	cfg, err = ParseFlags([]string{"merge", "--source-branch", "deps/"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.AuditLogPath(); err != nil || got != "" {
+		t.Errorf("AuditLogPath() = %q, %v, want disabled by GHPRMERGE_AUDIT_LOG", got, err)
+	}
+
+	// Without a user config directory there is no default, rather than a
+	// file in the working directory.
+	t.Setenv("GHPRMERGE_AUDIT_LOG", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	if got, err := cfg.AuditLogPath(); err == nil {
+		t.Errorf("AuditLogPath() = %q, want an error without a user config directory", got)
+	}
+}
+
+func TestParseFlagsHistory(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GITHUB_ORG", "")
+	t.Setenv("GHPRMERGE_HISTORY_FILE", "/tmp/env-history.jsonl")
+
+	cfg, err := ParseFlags([]string{"history", "--org", "myorg", "--since", "2026-03-01", "--json"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.Command != CommandHistory || !cfg.JSON || cfg.Org != "myorg" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !cfg.HistorySince.Equal(want) {
+		t.Errorf("HistorySince = %v, want %v", cfg.HistorySince, want)
+	}
+	if got, err := cfg.HistoryFilePath(); err != nil || got != "/tmp/env-history.jsonl" {
+		t.Errorf("HistoryFilePath() = %q, %v, want the GHPRMERGE_HISTORY_FILE value", got, err)
+	}
+
+	for _, args := range [][]string{
+		{"history", "--format", "csv"},
+		{"history", "--history-file", "off"},
+		{"history", "--since", "yesterday"},
+		{"history", "extra"},
+	} {
+		cfg, err := ParseFlags(args, "test")
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err == nil {
+			t.Errorf("accepted %v, want error", args)
+		}
+	}
+
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	cfg, err = ParseFlags([]string{"merge", "--org", "myorg", "--source-branch", "deps/", "--history-file", "off"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.HistoryFilePath(); err != nil || got != "" {
+		t.Errorf("HistoryFilePath() = %q, %v, want disabled", got, err)
+	}
+	if _, err := ParseFlags([]string{"report", "--history-file", "x"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --history-file on report")
+	}
+
+	cfg, err = ParseFlags([]string{"close", "--org", "myorg", "--source-branch", "deps/", "--no-history"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.HistoryFilePath(); err != nil || got != "" {
+		t.Errorf("HistoryFilePath() = %q, %v, want disabled by --no-history", got, err)
+	}
+	cfg, err = ParseFlags([]string{"merge", "--org", "myorg", "--source-branch", "deps/", "--no-history", "--history-file", "x"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --no-history with --history-file")
+	}
+
+	t.Setenv("GHPRMERGE_HISTORY_FILE", "off")
+	cfg, err = ParseFlags([]string{"merge", "--org", "myorg", "--source-branch", "deps/"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if got, err := cfg.HistoryFilePath(); err != nil || got != "" {
+		t.Errorf("HistoryFilePath() = %q, %v, want disabled by GHPRMERGE_HISTORY_FILE", got, err)
+	}
+
+	// Without a user config directory there is no default, rather than a
+	// file in the working directory.
+	t.Setenv("GHPRMERGE_HISTORY_FILE", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "")
+	if got, err := cfg.HistoryFilePath(); err == nil {
+		t.Errorf("HistoryFilePath() = %q, want an error without a user config directory", got)
+	}
+}
+
+func TestParseFlagsFromReport(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"merge", "--from-report", "report.json", "--group", "dependabot/npm/lodash", "--group", "dependabot/npm/axios"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if cfg.FromReport != "report.json" {
+		t.Errorf("FromReport = %q, want report.json", cfg.FromReport)
+	}
+	if want := []string{"dependabot/npm/lodash", "dependabot/npm/axios"}; !reflect.DeepEqual(cfg.ReportGroups, want) {
+		t.Errorf("ReportGroups = %v, want %v", cfg.ReportGroups, want)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"close", "--from-report", "report.json"}, "--from-report requires at least one --group"},
+		{[]string{"close", "--source-branch", "deps/", "--group", "deps/a"}, "--group requires --from-report"},
+		{[]string{"rebase", "--from-report", "report.json", "--group", "deps/a", "--source-branch", "deps/"}, "--source-branch cannot be used with --from-report"},
+	}
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFlagsAge(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"close", "--source-branch", "deps/", "--min-age", "14d", "--max-age", "720h"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if cfg.MinAge != 14*24*time.Hour || cfg.MaxAge != 30*24*time.Hour {
+		t.Errorf("MinAge = %v, MaxAge = %v, want 336h and 720h", cfg.MinAge, cfg.MaxAge)
+	}
+
+	for _, value := range []string{"14", "two weeks", "0d", "-3d"} {
+		if _, err := ParseFlags([]string{"report", "--min-age", value}, "test"); err == nil {
+			t.Errorf("ParseFlags() accepted --min-age %q", value)
+		}
+	}
+
+	cfg, err = ParseFlags([]string{"merge", "--source-branch", "deps/", "--min-age", "30d", "--max-age", "7d"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --min-age greater than --max-age")
+	}
+}
+
+func TestParseFlagsStaleView(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"report", "--view", "stale", "--stale-after", "30d"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if cfg.View != "stale" || cfg.StaleAfter != 30*24*time.Hour {
+		t.Errorf("View = %q, StaleAfter = %v, want stale and 720h", cfg.View, cfg.StaleAfter)
+	}
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"report", "--view", "oldest"}, "--view must be one of"},
+		{[]string{"report", "--stale-after", "30d"}, "--stale-after requires --view stale or --compare"},
+		{[]string{"report", "--view", "stale", "--group-by", "package"}, "--group-by cannot be used with --view stale"},
+		{[]string{"report", "--view", "stale", "--compare", "last.json"}, "--compare cannot be used with --view stale"},
+	}
+	for _, tt := range tests {
+		cfg, err := ParseFlags(tt.args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", tt.args, err)
+		}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%v) error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseFlagsSuperseded(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"close", "--source-branch", "dependabot/", "--superseded", "--delete-source-branch"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if !cfg.Superseded {
+		t.Error("Superseded = false, want true")
+	}
+
+	cfg, err = ParseFlags([]string{"close", "--source-branch", "dependabot/", "--superseded", "--dependabot-ignore", "dependency"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() accepted --superseded with --dependabot-ignore")
+	}
+	if _, err := ParseFlags([]string{"merge", "--source-branch", "dependabot/", "--superseded"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --superseded on merge")
+	}
+}
+
+func TestParseFlagsStale(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "test-token")
+	t.Setenv("GITHUB_ORG", "myorg")
+
+	cfg, err := ParseFlags([]string{"close", "--source-branch", "feature/", "--stale", "--inactive-for", "30d", "--failing-for", "14d", "--conflicted", "--close-comment", "Closing: {reason}"}, "test")
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if !cfg.Stale || cfg.InactiveFor != 30*24*time.Hour || cfg.FailingFor != 14*24*time.Hour || !cfg.Conflicted || cfg.CloseComment != "Closing: {reason}" {
+		t.Errorf("config = %+v, want stale criteria set", cfg)
+	}
+
+	invalid := [][]string{
+		{"close", "--source-branch", "feature/", "--stale"},
+		{"close", "--source-branch", "feature/", "--conflicted"},
+		{"close", "--source-branch", "feature/", "--stale", "--conflicted", "--superseded"},
+		{"close", "--source-branch", "feature/", "--superseded", "--close-comment", "bye"},
+		{"close", "--source-branch", "feature/", "--close-comment", "Closing: {reason}"},
+	}
+	for _, args := range invalid {
+		cfg, err := ParseFlags(args, "test")
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", args, err)
+		}
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate() accepted %v", args)
+		}
+	}
+	if _, err := ParseFlags([]string{"merge", "--source-branch", "feature/", "--stale"}, "test"); err == nil {
+		t.Error("ParseFlags() accepted --stale on merge")
+	}
+}
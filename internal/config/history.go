@@ -0,0 +1,91 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/audit"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/history"
+)
+
+// parseHistoryFlags parses the history command: ghprmerge history [flags]. It
+// reads the local run history and needs neither an organization nor a token.
+func parseHistoryFlags(args []string) (*Config, error) {
+	fs := flag.NewFlagSet(string(CommandHistory), flag.ContinueOnError)
+	fs.Usage = func() {
+		printHistoryUsage(fs.Output())
+	}
+	historyFile := fs.String("history-file", "", "History file to read (default GHPRMERGE_HISTORY_FILE or the user config directory)")
+	org := fs.String("org", "", "Only include runs against this organization")
+	since := fs.String("since", "", "Only include runs at or after this UTC date (YYYY-MM-DD) or RFC 3339 time")
+	jsonOutput := fs.Bool("json", false, "Output the trends as JSON")
+	format := fs.String("format", "", "Output format: text or json")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("history takes no arguments, got %q", fs.Arg(0))
+	}
+
+	cfg := &Config{
+		Command:     CommandHistory,
+		Org:         *org,
+		HistoryFile: *historyFile,
+		JSON:        *jsonOutput || *format == "json",
+		Format:      *format,
+	}
+	if *since != "" {
+		var err error
+		if cfg.HistorySince, err = audit.ParseTime(*since, false); err != nil {
+			return nil, fmt.Errorf("--since: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// validateHistory checks the history command's format and file.
+func (c *Config) validateHistory() error {
+	switch c.Format {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("--format must be one of: text, json")
+	}
+	if c.HistoryFile == history.Off {
+		return fmt.Errorf("--history-file off cannot be used with the history command")
+	}
+	return nil
+}
+
+// HistoryFilePath returns the history file to use: --history-file, or the
+// default path. It returns "" when recording is turned off, by --no-history,
+// --history-file off, or GHPRMERGE_HISTORY_FILE=off, and an error when there
+// is no default path.
+func (c *Config) HistoryFilePath() (string, error) {
+	if c.NoHistory {
+		return "", nil
+	}
+	path := c.HistoryFile
+	if path == "" {
+		var err error
+		if path, err = history.DefaultPath(); err != nil {
+			return "", err
+		}
+	}
+	if path == history.Off {
+		return "", nil
+	}
+	return path, nil
+}
+
+func printHistoryUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n  %s history [flags]\n\n", commandName())
+	fmt.Fprintln(w, "history summarizes the runs recorded by merge, rebase, and close: pull requests merged per week, how long Dependabot pull requests stay open, repositories that repeatedly fail checks or conflict, and skip reasons over time. It does not contact GitHub.")
+	fmt.Fprintln(w, "\nHistory flags:")
+	fmt.Fprintln(w, "  --history-file <path>      History file to read (default GHPRMERGE_HISTORY_FILE or <config dir>/ghprmerge/history.jsonl).")
+	fmt.Fprintln(w, "  --org <organization>       Only include runs against this organization.")
+	fmt.Fprintln(w, "  --since <time>             Only include runs at or after this UTC date (YYYY-MM-DD) or RFC 3339 time.")
+	fmt.Fprintln(w, "  --json                     Output the trends as JSON.")
+	fmt.Fprintln(w, "  --format <format>          Output format: text (default) or json.")
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// parseSchemaFlags parses the schema command: ghprmerge schema [--json-casing snake] <document>.
+// It needs neither an organization nor a token.
+func parseSchemaFlags(args []string) (*Config, error) {
+	fs := flag.NewFlagSet(string(CommandSchema), flag.ContinueOnError)
+	fs.Usage = func() {
+		printSchemaUsage(fs.Output())
+	}
+	jsonCasing := fs.String("json-casing", "", "Key casing of the report schema: camel (default) or snake")
+
+	// Flags may appear before or after the document name.
+	var documents []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		documents = append(documents, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(documents) > 1 {
+		return nil, fmt.Errorf("schema accepts a single document, got %d", len(documents))
+	}
+
+	cfg := &Config{Command: CommandSchema, JSONCasing: *jsonCasing}
+	if len(documents) == 1 {
+		cfg.SchemaDocument = documents[0]
+	}
+	return cfg, nil
+}
+
+// validateSchema checks the schema command's document and casing.
+func (c *Config) validateSchema() error {
+	if c.SchemaDocument == "" {
+		return fmt.Errorf("schema requires a document: %s", strings.Join(output.SchemaDocuments, ", "))
+	}
+	if !slices.Contains(output.SchemaDocuments, c.SchemaDocument) {
+		return fmt.Errorf("unknown schema document %q: must be one of %s", c.SchemaDocument, strings.Join(output.SchemaDocuments, ", "))
+	}
+	return validateJSONCasing(c.JSONCasing)
+}
+
+// validateJSONCasing checks a --json-casing value.
+func validateJSONCasing(casing string) error {
+	switch casing {
+	case "", "camel", "snake":
+		return nil
+	}
+	return fmt.Errorf("--json-casing must be one of: camel, snake")
+}
+
+func printSchemaUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage:\n  %s schema [--json-casing <casing>] <document>\n\n", commandName())
+	fmt.Fprintln(w, "schema prints the JSON Schema of a JSON output document. It does not contact GitHub.")
+	fmt.Fprintln(w, "\nDocuments:")
+	fmt.Fprintln(w, "  run                        --json output of merge, rebase, and close.")
+	fmt.Fprintln(w, "  report                     --json output of report.")
+	fmt.Fprintln(w, "  event                      Each line of --format ndjson output.")
+	fmt.Fprintln(w, "  audit                      --json output of audit.")
+	fmt.Fprintln(w, "  history                    --json output of history.")
+	fmt.Fprintln(w, "\nSchema flags:")
+	fmt.Fprintln(w, "  --json-casing <casing>     Key casing of the report schema: camel (default) or snake.")
+}
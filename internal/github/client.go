@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ type PullRequest struct {
 	HeadBranch       string
 	BaseBranch       string
 	State            string
+	Merged           bool
 	Draft            bool
 	Mergeable        *bool
 	HeadSHA          string
@@ -35,6 +37,10 @@ type PullRequest struct {
 	RepoFullName     string
 	HeadRepoFullName string
 	Author           string
+	Body             string
+	Labels           []string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 // CheckStatus represents the overall status of checks on a commit.
@@ -43,6 +49,10 @@ type CheckStatus struct {
 	Pending    bool
 	NoChecks   bool
 	Details    string
+	// FailingSince is when the failing check first failed on the commit, so
+	// re-running it does not reset the time. It is zero when no check failed
+	// or the time is unknown.
+	FailingSince time.Time
 }
 
 // BranchStatus represents the status of a branch relative to its base.
@@ -52,6 +62,13 @@ type BranchStatus struct {
 	HasConflict bool
 }
 
+// Comment represents a comment on a pull request.
+type Comment struct {
+	ID     int64
+	Body   string
+	Author string
+}
+
 // ActionResult represents the result of an action on a pull request.
 type ActionResult struct {
 	Action  string
@@ -82,6 +99,27 @@ type Client interface {
 	// PostRebaseComment posts a rebase comment on a pull request for Dependabot.
 	PostRebaseComment(ctx context.Context, owner, repo string, prNumber int) error
 
+	// PostComment posts a comment on a pull request.
+	PostComment(ctx context.Context, owner, repo string, prNumber int, body string) error
+
+	// ListComments lists the comments on a pull request.
+	ListComments(ctx context.Context, owner, repo string, prNumber int) ([]Comment, error)
+
+	// UpdateComment replaces the body of a comment on pull request prNumber.
+	UpdateComment(ctx context.Context, owner, repo string, prNumber int, commentID int64, body string) error
+
+	// DeleteComment deletes a comment on pull request prNumber.
+	DeleteComment(ctx context.Context, owner, repo string, prNumber int, commentID int64) error
+
+	// UpdatePullRequestBody replaces the description of a pull request.
+	UpdatePullRequestBody(ctx context.Context, owner, repo string, prNumber int, body string) error
+
+	// AddLabels adds labels to a pull request, creating them in the repository if needed.
+	AddLabels(ctx context.Context, owner, repo string, prNumber int, labels []string) error
+
+	// RemoveLabel removes a label from a pull request.
+	RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error
+
 	// MergePullRequest merges a pull request.
 	MergePullRequest(ctx context.Context, owner, repo string, prNumber int) error
 
@@ -90,6 +128,9 @@ type Client interface {
 
 	// DeleteBranch deletes a branch from a repository.
 	DeleteBranch(ctx context.Context, owner, repo, branch string) error
+
+	// AuthenticatedUser returns the login of the token's owner.
+	AuthenticatedUser(ctx context.Context) (string, error)
 }
 
 // RealClient implements the Client interface using the real GitHub API.
@@ -175,6 +216,10 @@ func (c *RealClient) ListPullRequests(ctx context.Context, owner, repo, defaultB
 				RepoFullName:     fmt.Sprintf("%s/%s", owner, repo),
 				HeadRepoFullName: pr.GetHead().GetRepo().GetFullName(),
 				Author:           pr.GetUser().GetLogin(),
+				Body:             pr.GetBody(),
+				Labels:           labelNames(pr.Labels),
+				CreatedAt:        pr.GetCreatedAt().Time,
+				UpdatedAt:        pr.GetUpdatedAt().Time,
 			})
 		}
 
@@ -201,6 +246,7 @@ func (c *RealClient) GetPullRequest(ctx context.Context, owner, repo string, num
 		HeadBranch:       pr.GetHead().GetRef(),
 		BaseBranch:       pr.GetBase().GetRef(),
 		State:            pr.GetState(),
+		Merged:           pr.GetMerged(),
 		Draft:            pr.GetDraft(),
 		Mergeable:        pr.Mergeable,
 		HeadSHA:          pr.GetHead().GetSHA(),
@@ -208,6 +254,10 @@ func (c *RealClient) GetPullRequest(ctx context.Context, owner, repo string, num
 		RepoFullName:     fmt.Sprintf("%s/%s", owner, repo),
 		HeadRepoFullName: pr.GetHead().GetRepo().GetFullName(),
 		Author:           pr.GetUser().GetLogin(),
+		Body:             pr.GetBody(),
+		Labels:           labelNames(pr.Labels),
+		CreatedAt:        pr.GetCreatedAt().Time,
+		UpdatedAt:        pr.GetUpdatedAt().Time,
 	}, nil
 }
 
@@ -253,8 +303,9 @@ func (c *RealClient) GetCheckStatus(ctx context.Context, owner, repo, ref string
 		// Only "success" is considered passing
 		if conclusion != "success" {
 			return &CheckStatus{
-				AllPassing: false,
-				Details:    fmt.Sprintf("check '%s' has conclusion '%s'", check.GetName(), conclusion),
+				AllPassing:   false,
+				Details:      fmt.Sprintf("check '%s' has conclusion '%s'", check.GetName(), conclusion),
+				FailingSince: c.checkFailingSince(ctx, owner, repo, ref, check),
 			}, nil
 		}
 	}
@@ -271,8 +322,9 @@ func (c *RealClient) GetCheckStatus(ctx context.Context, owner, repo, ref string
 		}
 		if state != "success" {
 			return &CheckStatus{
-				AllPassing: false,
-				Details:    fmt.Sprintf("status '%s' has state '%s'", status.GetContext(), state),
+				AllPassing:   false,
+				Details:      fmt.Sprintf("status '%s' has state '%s'", status.GetContext(), state),
+				FailingSince: c.statusFailingSince(ctx, owner, repo, ref, status),
 			}, nil
 		}
 	}
@@ -283,6 +335,80 @@ func (c *RealClient) GetCheckStatus(ctx context.Context, owner, repo, ref string
 	}, nil
 }
 
+// checkFailingSince returns when the failing check run first failed on ref:
+// the completion time of the oldest run of the same check in the series of
+// failures that ends with check. It falls back to check's own completion time
+// when the earlier runs cannot be listed.
+func (c *RealClient) checkFailingSince(ctx context.Context, owner, repo, ref string, check *github.CheckRun) time.Time {
+	opts := &github.ListCheckRunsOptions{
+		CheckName:   github.String(check.GetName()),
+		Status:      github.String("completed"),
+		Filter:      github.String("all"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	if appID := check.GetApp().GetID(); appID != 0 {
+		opts.AppID = github.Int64(appID)
+	}
+	runs, _, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+	if err != nil {
+		return check.GetCompletedAt().Time
+	}
+	return checkRunsFailingSince(runs.CheckRuns, check.GetCompletedAt().Time)
+}
+
+// checkRunsFailingSince walks runs of one check from newest to oldest and
+// returns the completion time of the oldest failure before a successful run,
+// or latest if none is older.
+func checkRunsFailingSince(runs []*github.CheckRun, latest time.Time) time.Time {
+	runs = slices.Clone(runs)
+	slices.SortFunc(runs, func(a, b *github.CheckRun) int {
+		return b.GetCompletedAt().Time.Compare(a.GetCompletedAt().Time)
+	})
+	since := latest
+	for _, run := range runs {
+		if run.GetConclusion() == "success" {
+			break
+		}
+		if at := run.GetCompletedAt().Time; !at.IsZero() && at.Before(since) {
+			since = at
+		}
+	}
+	return since
+}
+
+// statusFailingSince returns when the failing commit status first failed on
+// ref, the same way checkFailingSince does for check runs.
+func (c *RealClient) statusFailingSince(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) time.Time {
+	statuses, _, err := c.client.Repositories.ListStatuses(ctx, owner, repo, ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return status.GetUpdatedAt().Time
+	}
+	return statusesFailingSince(statuses, status.GetContext(), status.GetUpdatedAt().Time)
+}
+
+// statusesFailingSince walks the statuses of the named context from newest to oldest
+// and returns the time of the oldest failure before a successful status, or
+// latest if none is older. Pending statuses from re-runs are passed over.
+func statusesFailingSince(statuses []*github.RepoStatus, name string, latest time.Time) time.Time {
+	statuses = slices.Clone(statuses)
+	slices.SortFunc(statuses, func(a, b *github.RepoStatus) int {
+		return b.GetUpdatedAt().Time.Compare(a.GetUpdatedAt().Time)
+	})
+	since := latest
+	for _, status := range statuses {
+		if status.GetContext() != name || status.GetState() == "pending" {
+			continue
+		}
+		if status.GetState() == "success" {
+			break
+		}
+		if at := status.GetUpdatedAt().Time; !at.IsZero() && at.Before(since) {
+			since = at
+		}
+	}
+	return since
+}
+
 // GetBranchStatus gets the status of a PR branch relative to its base.
 func (c *RealClient) GetBranchStatus(ctx context.Context, owner, repo string, prNumber int) (*BranchStatus, error) {
 	// Get fresh PR data to check mergeability
@@ -354,7 +480,7 @@ func (c *RealClient) UpdateBranch(ctx context.Context, owner, repo string, prNum
 // PostRebaseComment posts a rebase comment on a pull request for Dependabot.
 func (c *RealClient) PostRebaseComment(ctx context.Context, owner, repo string, prNumber int) error {
 	comment := &github.IssueComment{
-		Body: new("@dependabot rebase"),
+		Body: new(DependabotRebaseCommand),
 	}
 	_, _, err := c.client.Issues.CreateComment(ctx, owner, repo, prNumber, comment)
 	if err != nil {
@@ -363,6 +489,91 @@ func (c *RealClient) PostRebaseComment(ctx context.Context, owner, repo string,
 	return nil
 }
 
+// PostComment posts a comment on a pull request.
+func (c *RealClient) PostComment(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	_, _, err := c.client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{Body: &body})
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	return nil
+}
+
+// ListComments lists the comments on a pull request.
+func (c *RealClient) ListComments(ctx context.Context, owner, repo string, prNumber int) ([]Comment, error) {
+	var allComments []Comment
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		comments, resp, err := c.client.Issues.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list comments: %w", err)
+		}
+
+		for _, comment := range comments {
+			allComments = append(allComments, Comment{
+				ID:     comment.GetID(),
+				Body:   comment.GetBody(),
+				Author: comment.GetUser().GetLogin(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allComments, nil
+}
+
+// UpdateComment replaces the body of a pull request comment.
+// GitHub addresses the comment by ID alone; prNumber is not needed.
+func (c *RealClient) UpdateComment(ctx context.Context, owner, repo string, prNumber int, commentID int64, body string) error {
+	_, _, err := c.client.Issues.EditComment(ctx, owner, repo, commentID, &github.IssueComment{Body: &body})
+	if err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+	return nil
+}
+
+// DeleteComment deletes a pull request comment.
+func (c *RealClient) DeleteComment(ctx context.Context, owner, repo string, prNumber int, commentID int64) error {
+	_, err := c.client.Issues.DeleteComment(ctx, owner, repo, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+// UpdatePullRequestBody replaces the description of a pull request.
+func (c *RealClient) UpdatePullRequestBody(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	_, _, err := c.client.PullRequests.Edit(ctx, owner, repo, prNumber, &github.PullRequest{Body: &body})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request body: %w", err)
+	}
+	return nil
+}
+
+// AddLabels adds labels to a pull request, creating them in the repository if needed.
+func (c *RealClient) AddLabels(ctx context.Context, owner, repo string, prNumber int, labels []string) error {
+	_, _, err := c.client.Issues.AddLabelsToIssue(ctx, owner, repo, prNumber, labels)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+	return nil
+}
+
+// RemoveLabel removes a label from a pull request.
+func (c *RealClient) RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error {
+	_, err := c.client.Issues.RemoveLabelForIssue(ctx, owner, repo, prNumber, label)
+	if err != nil {
+		return fmt.Errorf("failed to remove label: %w", err)
+	}
+	return nil
+}
+
 // MergePullRequest merges a pull request.
 func (c *RealClient) MergePullRequest(ctx context.Context, owner, repo string, prNumber int) error {
 	_, _, err := c.client.PullRequests.Merge(ctx, owner, repo, prNumber, "", &github.PullRequestOptions{})
@@ -391,11 +602,44 @@ func (c *RealClient) DeleteBranch(ctx context.Context, owner, repo, branch strin
 	return nil
 }
 
+// AuthenticatedUser returns the login of the token's owner.
+func (c *RealClient) AuthenticatedUser(ctx context.Context) (string, error) {
+	user, _, err := c.client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+	return user.GetLogin(), nil
+}
+
+// labelNames returns the names of the given labels.
+func labelNames(labels []*github.Label) []string {
+	var names []string
+	for _, l := range labels {
+		names = append(names, l.GetName())
+	}
+	return names
+}
+
+// HasLabel checks if a pull request carries the given label.
+func (pr PullRequest) HasLabel(label string) bool {
+	return slices.Contains(pr.Labels, label)
+}
+
 // IsDependabotBranch checks if a branch name indicates Dependabot ownership.
 func IsDependabotBranch(branchName string) bool {
 	return strings.HasPrefix(branchName, "dependabot/")
 }
 
+// IsDependabotPullRequest checks if a pull request was opened by Dependabot.
+func IsDependabotPullRequest(pr PullRequest) bool {
+	return IsDependabotBranch(pr.HeadBranch) || pr.Author == "dependabot[bot]"
+}
+
+// IsRenovateBranch checks if a branch name indicates Renovate ownership.
+func IsRenovateBranch(branchName string) bool {
+	return strings.HasPrefix(branchName, "renovate/")
+}
+
 // MatchesBranchPattern checks if a branch name matches the given pattern using substring matching.
 func MatchesBranchPattern(branchName, pattern string) bool {
 	return strings.Contains(branchName, pattern)
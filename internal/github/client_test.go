@@ -2,6 +2,9 @@ package github
 
 import (
 	"testing"
+	"time"
+
+	"github.com/google/go-github/v60/github"
 )
 
 func TestIsDependabotBranch(t *testing.T) {
@@ -93,3 +96,47 @@ func TestMatchesBranchPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRunsFailingSince(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	run := func(conclusion string, d int) *github.CheckRun {
+		return &github.CheckRun{Conclusion: github.String(conclusion), CompletedAt: &github.Timestamp{Time: day(d)}}
+	}
+
+	tests := []struct {
+		name string
+		runs []*github.CheckRun
+		want time.Time
+	}{
+		{"single failure", []*github.CheckRun{run("failure", 20)}, day(20)},
+		{"re-runs keep the first failure", []*github.CheckRun{run("failure", 20), run("failure", 1), run("timed_out", 10)}, day(1)},
+		{"a success ends the series", []*github.CheckRun{run("failure", 2), run("success", 5), run("failure", 20), run("failure", 10)}, day(10)},
+		{"listing failed", nil, day(20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkRunsFailingSince(tt.runs, day(20)); !got.Equal(tt.want) {
+				t.Errorf("checkRunsFailingSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusesFailingSince(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	status := func(name, state string, d int) *github.RepoStatus {
+		return &github.RepoStatus{Context: github.String(name), State: github.String(state), UpdatedAt: &github.Timestamp{Time: day(d)}}
+	}
+
+	statuses := []*github.RepoStatus{
+		status("ci", "failure", 20),
+		status("ci", "pending", 19),
+		status("lint", "success", 15),
+		status("ci", "error", 8),
+		status("ci", "success", 3),
+		status("ci", "failure", 1),
+	}
+	if got := statusesFailingSince(statuses, "ci", day(20)); !got.Equal(day(8)) {
+		t.Errorf("statusesFailingSince() = %v, want %v", got, day(8))
+	}
+}
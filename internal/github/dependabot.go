@@ -0,0 +1,34 @@
+package github
+
+import "fmt"
+
+// Dependabot comment commands.
+const (
+	DependabotRebaseCommand   = "@dependabot rebase"
+	DependabotRecreateCommand = "@dependabot recreate"
+)
+
+// Dependabot ignore scopes accepted by the close command.
+const (
+	IgnoreMajorVersion = "major-version"
+	IgnoreMinorVersion = "minor-version"
+	IgnoreDependency   = "dependency"
+)
+
+// IgnoreScopes lists the valid Dependabot ignore scopes.
+var IgnoreScopes = []string{IgnoreMajorVersion, IgnoreMinorVersion, IgnoreDependency}
+
+// DependabotIgnoreCommand returns the comment that tells Dependabot to close a
+// pull request and stop proposing updates within the given scope.
+func DependabotIgnoreCommand(scope string) (string, error) {
+	switch scope {
+	case IgnoreMajorVersion:
+		return "@dependabot ignore this major version", nil
+	case IgnoreMinorVersion:
+		return "@dependabot ignore this minor version", nil
+	case IgnoreDependency:
+		return "@dependabot ignore this dependency", nil
+	default:
+		return "", fmt.Errorf("unknown Dependabot ignore scope %q", scope)
+	}
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dependency is the dependency update a bot pull request proposes. Fields
+// that cannot be determined are empty.
+type Dependency struct {
+	Ecosystem string // Dependabot package ecosystem, e.g. npm_and_yarn
+	Directory string // Dependabot manifest directory, e.g. / or /frontend
+	Package   string
+	Version   string // target version, without a leading "v"
+}
+
+var (
+	// "Bump lodash from 4.17.20 to 4.17.21 in /frontend", optionally behind a
+	// "[Security] " tag or a conventional commit prefix such as "chore(deps): ".
+	dependabotTitle = regexp.MustCompile(`(?i)^(?:\[\w+\] )?(?:\S+: )?bump (\S+) from \S+ to (\S+)(?: in (/\S*))?`)
+	// "Update dependency lodash to v4.17.21", "Update actions/checkout action to v4".
+	renovateTitle = regexp.MustCompile(`(?i)^(?:\S+: )?update (?:dependency |module |docker tag )?(\S+)(?: action| digest| docker tag)? to (\S+)`)
+	// "Bump the npm-deps group with 3 updates": a grouped update has no single package.
+	groupedTitle = regexp.MustCompile(`(?i)^(?:\S+: )?bump the \S+ group\b`)
+	// The "-<version>" suffix of a bot branch, e.g. "-4.17.21", "-4.x" or "-2.0.0-rc.1".
+	branchVersion = regexp.MustCompile(`-(v?\d[\w.]*(?:-[a-zA-Z][\w.]*)?)$`)
+)
+
+// ParseDependency determines the package and target version a Dependabot or
+// Renovate pull request updates. The title is preferred because it carries
+// the full package name; the branch name is the fallback and always provides
+// the Dependabot ecosystem. Grouped updates that bump several packages have
+// no single package. The Dependabot directory comes from the title, or from
+// the branch path when the title names the package; a branch alone cannot
+// tell a directory apart from a package name containing slashes, so the
+// directory is left empty then.
+func ParseDependency(pr PullRequest) Dependency {
+	var dep Dependency
+	var rest string
+	switch {
+	case IsDependabotBranch(pr.HeadBranch):
+		dep.Ecosystem, rest, _ = strings.Cut(strings.TrimPrefix(pr.HeadBranch, "dependabot/"), "/")
+	case IsRenovateBranch(pr.HeadBranch):
+		rest = strings.TrimPrefix(pr.HeadBranch, "renovate/")
+	default:
+		return dep
+	}
+
+	if groupedTitle.MatchString(pr.Title) {
+		return dep
+	}
+	for _, pattern := range []*regexp.Regexp{dependabotTitle, renovateTitle} {
+		if match := pattern.FindStringSubmatch(pr.Title); match != nil {
+			dep.Package = match[1]
+			dep.Version = normalizeVersion(match[2])
+			switch {
+			case len(match) > 3 && match[3] != "":
+				dep.Directory = match[3]
+			case dep.Ecosystem != "":
+				dep.Directory = branchDirectory(rest, dep.Package)
+			}
+			return dep
+		}
+	}
+
+	// Branch fallback: the last path segment is "<package>-<version>".
+	name := rest[strings.LastIndex(rest, "/")+1:]
+	if loc := branchVersion.FindStringSubmatchIndex(name); loc != nil && loc[0] > 0 {
+		dep.Package = name[:loc[0]]
+		dep.Version = normalizeVersion(name[loc[2]:loc[3]])
+	}
+	return dep
+}
+
+// branchDirectory returns the manifest directory of a Dependabot branch whose
+// path after the ecosystem is "<directory>/<package>-<version>", or "" when
+// the path does not end in the package.
+func branchDirectory(rest, pkg string) string {
+	loc := branchVersion.FindStringIndex(rest)
+	if loc == nil {
+		return ""
+	}
+	name := rest[:loc[0]]
+	pkg = strings.TrimPrefix(pkg, "@")
+	if name == pkg {
+		return "/"
+	}
+	if dir, ok := strings.CutSuffix(name, "/"+pkg); ok && dir != "" {
+		return "/" + dir
+	}
+	return ""
+}
+
+// normalizeVersion strips a leading "v" and trailing punctuation so that
+// "v4", "4" and "4." compare equal.
+func normalizeVersion(version string) string {
+	version = strings.TrimRight(version, ".,;:")
+	if len(version) > 1 && (version[0] == 'v' || version[0] == 'V') && version[1] >= '0' && version[1] <= '9' {
+		version = version[1:]
+	}
+	return version
+}
+
+// CompareVersions compares two dependency versions segment by segment,
+// numerically where both segments are numbers, and returns -1, 0, or +1.
+// Segments are separated by ".", "-", or "+", so "1.10.0" is newer than
+// "1.9.2". A prerelease, the part after the first "-", ranks below its
+// release, so "2.0.0-rc.1" is older than "2.0.0".
+func CompareVersions(a, b string) int {
+	aRelease, aPre, aIsPre := strings.Cut(normalizeVersion(a), "-")
+	bRelease, bPre, bIsPre := strings.Cut(normalizeVersion(b), "-")
+	switch c := compareSegments(aRelease, bRelease); {
+	case c != 0:
+		return c
+	case !aIsPre && !bIsPre:
+		return 0
+	case !aIsPre:
+		return 1
+	case !bIsPre:
+		return -1
+	}
+	return compareSegments(aPre, bPre)
+}
+
+// compareSegments compares the ".", "-", or "+" separated segments of two
+// version parts for CompareVersions.
+func compareSegments(a, b string) int {
+	split := func(part string) []string {
+		return strings.FieldsFunc(part, func(r rune) bool {
+			return r == '.' || r == '-' || r == '+'
+		})
+	}
+	as, bs := split(a), split(b)
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		if i >= len(as) {
+			return -1
+		}
+		if i >= len(bs) {
+			return 1
+		}
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		var c int
+		if aErr == nil && bErr == nil {
+			c = an - bn
+		} else {
+			c = strings.Compare(as[i], bs[i])
+		}
+		switch {
+		case c < 0:
+			return -1
+		case c > 0:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+package github
+
+import "testing"
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		title  string
+		want   Dependency
+	}{
+		{
+			name:   "dependabot title",
+			branch: "dependabot/npm_and_yarn/lodash-4.17.21",
+			title:  "Bump lodash from 4.17.20 to 4.17.21",
+			want:   Dependency{Ecosystem: "npm_and_yarn", Directory: "/", Package: "lodash", Version: "4.17.21"},
+		},
+		{
+			name:   "dependabot title with directory and prefix",
+			branch: "dependabot/npm_and_yarn/frontend/lodash-4.17.21",
+			title:  "chore(deps): bump lodash from 4.17.20 to 4.17.21 in /frontend",
+			want:   Dependency{Ecosystem: "npm_and_yarn", Directory: "/frontend", Package: "lodash", Version: "4.17.21"},
+		},
+		{
+			name:   "dependabot action with owner",
+			branch: "dependabot/github_actions/actions/checkout-4",
+			title:  "Bump actions/checkout from v3 to v4",
+			want:   Dependency{Ecosystem: "github_actions", Directory: "/", Package: "actions/checkout", Version: "4"},
+		},
+		{
+			name:   "dependabot security title",
+			branch: "dependabot/npm_and_yarn/frontend/lodash-4.17.21",
+			title:  "[Security] Bump lodash from 4.17.20 to 4.17.21",
+			want:   Dependency{Ecosystem: "npm_and_yarn", Directory: "/frontend", Package: "lodash", Version: "4.17.21"},
+		},
+		{
+			name:   "dependabot scoped package directory from branch",
+			branch: "dependabot/npm_and_yarn/web/types/node-20.1.0",
+			title:  "Bump @types/node from 20.0.0 to 20.1.0",
+			want:   Dependency{Ecosystem: "npm_and_yarn", Directory: "/web", Package: "@types/node", Version: "20.1.0"},
+		},
+		{
+			name:   "dependabot branch fallback",
+			branch: "dependabot/go_modules/golang.org/x/text-0.3.7",
+			title:  "Custom title",
+			want:   Dependency{Ecosystem: "go_modules", Package: "text", Version: "0.3.7"},
+		},
+		{
+			name:   "dependabot prerelease branch fallback",
+			branch: "dependabot/npm_and_yarn/react-19.0.0-rc.1",
+			title:  "Custom title",
+			want:   Dependency{Ecosystem: "npm_and_yarn", Package: "react", Version: "19.0.0-rc.1"},
+		},
+		{
+			name:   "dependabot grouped update",
+			branch: "dependabot/npm_and_yarn/npm-deps-5f2a1b",
+			title:  "Bump the npm-deps group with 3 updates",
+			want:   Dependency{Ecosystem: "npm_and_yarn"},
+		},
+		{
+			name:   "renovate dependency title",
+			branch: "renovate/lodash-4.x",
+			title:  "Update dependency lodash to v4.17.21",
+			want:   Dependency{Package: "lodash", Version: "4.17.21"},
+		},
+		{
+			name:   "renovate action title",
+			branch: "renovate/actions-checkout-4.x",
+			title:  "Update actions/checkout action to v4",
+			want:   Dependency{Package: "actions/checkout", Version: "4"},
+		},
+		{
+			name:   "renovate branch fallback",
+			branch: "renovate/lodash-4.x",
+			title:  "Update all non-major dependencies",
+			want:   Dependency{Package: "lodash", Version: "4.x"},
+		},
+		{
+			name:   "not a bot branch",
+			branch: "feature/bump-lodash-4",
+			title:  "Bump lodash from 4.17.20 to 4.17.21",
+			want:   Dependency{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseDependency(PullRequest{HeadBranch: tt.branch, Title: tt.title})
+			if got != tt.want {
+				t.Errorf("ParseDependency(%q, %q) = %+v, want %+v", tt.branch, tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.10.0", "1.9.2", 1},
+		{"4.17.20", "4.17.21", -1},
+		{"v4", "4", 0},
+		{"2.0.0", "2.0", 1},
+		{"1.0.0-beta.2", "1.0.0-beta.10", -1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		{"2.0.0-rc.1", "2.0.0", -1},
+		{"2.0.0", "2.0.0-rc.1", 1},
+		{"2.0.0-rc.2", "2.0.0-rc.1", 1},
+		{"2.0.1-rc.1", "2.0.0", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -3,29 +3,50 @@ package github
 
 import (
 	"context"
+	"slices"
 )
 
 // MockClient is a mock implementation of the Client interface for testing.
 type MockClient struct {
-	Repositories    []Repository
-	PullRequests    map[string][]PullRequest // key: "owner/repo"
-	CheckStatuses   map[string]*CheckStatus  // key: "owner/repo/ref"
-	BranchStatuses  map[string]*BranchStatus // key: "owner/repo/prNumber"
-	UpdateBranchErr map[string]error         // key: "owner/repo/prNumber"
-	PostRebaseErr   map[string]error         // key: "owner/repo/prNumber"
-	MergeErr        map[string]error         // key: "owner/repo/prNumber"
-	CloseErr        map[string]error         // key: "owner/repo/prNumber"
-	DeleteBranchErr map[string]error         // key: "owner/repo/branch"
-	ListReposErr    error
-	ListPRsErr      map[string]error // key: "owner/repo"
-	GetPRErr        map[string]error // key: "owner/repo/prNumber"
+	Repositories     []Repository
+	PullRequests     map[string][]PullRequest // key: "owner/repo"
+	CheckStatuses    map[string]*CheckStatus  // key: "owner/repo/ref"
+	BranchStatuses   map[string]*BranchStatus // key: "owner/repo/prNumber"
+	UpdateBranchErr  map[string]error         // key: "owner/repo/prNumber"
+	PostRebaseErr    map[string]error         // key: "owner/repo/prNumber"
+	PostCommentErr   map[string]error         // key: "owner/repo/prNumber"
+	UpdateBodyErr    map[string]error         // key: "owner/repo/prNumber"
+	ListCommentsErr  map[string]error         // key: "owner/repo/prNumber"
+	UpdateCommentErr map[int64]error          // key: comment ID
+	DeleteCommentErr map[int64]error          // key: comment ID
+	AddLabelsErr     map[string]error         // key: "owner/repo/prNumber"
+	RemoveLabelErr   map[string]error         // key: "owner/repo/prNumber"
+	MergeErr         map[string]error         // key: "owner/repo/prNumber"
+	CloseErr         map[string]error         // key: "owner/repo/prNumber"
+	DeleteBranchErr  map[string]error         // key: "owner/repo/branch"
+	ListReposErr     error
+	ListPRsErr       map[string]error // key: "owner/repo"
+	GetPRErr         map[string]error // key: "owner/repo/prNumber"
+	Login            string
+	LoginErr         error
 
 	// Track calls for verification
 	UpdateBranchCalls []string
 	PostRebaseCalls   []string
+	PostCommentCalls  []string
+	PostedComments    map[string][]string  // key: "owner/repo/prNumber"
+	Comments          map[string][]Comment // key: "owner/repo/prNumber"; PostComment appends here
+	UpdatedComments   map[int64]string
+	DeletedComments   []int64
+	UpdateBodyCalls   []string
+	UpdatedBodies     map[string]string   // key: "owner/repo/prNumber"
+	AddedLabels       map[string][]string // key: "owner/repo/prNumber"
+	RemovedLabels     map[string][]string // key: "owner/repo/prNumber"
 	MergeCalls        []string
 	CloseCalls        []string
 	DeleteBranchCalls []string
+
+	nextCommentID int64
 }
 
 // NewMockClient creates a new MockClient with initialized maps.
@@ -37,6 +58,13 @@ func NewMockClient() *MockClient {
 		BranchStatuses:    make(map[string]*BranchStatus),
 		UpdateBranchErr:   make(map[string]error),
 		PostRebaseErr:     make(map[string]error),
+		PostCommentErr:    make(map[string]error),
+		UpdateBodyErr:     make(map[string]error),
+		ListCommentsErr:   make(map[string]error),
+		UpdateCommentErr:  make(map[int64]error),
+		DeleteCommentErr:  make(map[int64]error),
+		AddLabelsErr:      make(map[string]error),
+		RemoveLabelErr:    make(map[string]error),
 		MergeErr:          make(map[string]error),
 		CloseErr:          make(map[string]error),
 		DeleteBranchErr:   make(map[string]error),
@@ -44,6 +72,15 @@ func NewMockClient() *MockClient {
 		GetPRErr:          make(map[string]error),
 		UpdateBranchCalls: []string{},
 		PostRebaseCalls:   []string{},
+		PostCommentCalls:  []string{},
+		PostedComments:    make(map[string][]string),
+		Comments:          make(map[string][]Comment),
+		UpdatedComments:   make(map[int64]string),
+		DeletedComments:   []int64{},
+		UpdateBodyCalls:   []string{},
+		UpdatedBodies:     make(map[string]string),
+		AddedLabels:       make(map[string][]string),
+		RemovedLabels:     make(map[string][]string),
 		MergeCalls:        []string{},
 		CloseCalls:        []string{},
 		DeleteBranchCalls: []string{},
@@ -126,6 +163,87 @@ func (m *MockClient) PostRebaseComment(ctx context.Context, owner, repo string,
 	return nil
 }
 
+// PostComment mocks posting a comment.
+func (m *MockClient) PostComment(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	key := owner + "/" + repo + "/" + string(rune(prNumber))
+	m.PostCommentCalls = append(m.PostCommentCalls, key)
+	if err, ok := m.PostCommentErr[key]; ok {
+		return err
+	}
+	m.PostedComments[key] = append(m.PostedComments[key], body)
+	m.nextCommentID++
+	m.Comments[key] = append(m.Comments[key], Comment{ID: m.nextCommentID, Body: body, Author: m.Login})
+	return nil
+}
+
+// ListComments returns mock comments.
+func (m *MockClient) ListComments(ctx context.Context, owner, repo string, prNumber int) ([]Comment, error) {
+	key := owner + "/" + repo + "/" + string(rune(prNumber))
+	if err, ok := m.ListCommentsErr[key]; ok {
+		return nil, err
+	}
+	return m.Comments[key], nil
+}
+
+// UpdateComment mocks editing a comment.
+func (m *MockClient) UpdateComment(ctx context.Context, owner, repo string, prNumber int, commentID int64, body string) error {
+	if err, ok := m.UpdateCommentErr[commentID]; ok {
+		return err
+	}
+	m.UpdatedComments[commentID] = body
+	for key, comments := range m.Comments {
+		for i := range comments {
+			if comments[i].ID == commentID {
+				m.Comments[key][i].Body = body
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteComment mocks deleting a comment.
+func (m *MockClient) DeleteComment(ctx context.Context, owner, repo string, prNumber int, commentID int64) error {
+	if err, ok := m.DeleteCommentErr[commentID]; ok {
+		return err
+	}
+	m.DeletedComments = append(m.DeletedComments, commentID)
+	for key, comments := range m.Comments {
+		m.Comments[key] = slices.DeleteFunc(comments, func(c Comment) bool { return c.ID == commentID })
+	}
+	return nil
+}
+
+// UpdatePullRequestBody mocks replacing a pull request description.
+func (m *MockClient) UpdatePullRequestBody(ctx context.Context, owner, repo string, prNumber int, body string) error {
+	key := owner + "/" + repo + "/" + string(rune(prNumber))
+	m.UpdateBodyCalls = append(m.UpdateBodyCalls, key)
+	if err, ok := m.UpdateBodyErr[key]; ok {
+		return err
+	}
+	m.UpdatedBodies[key] = body
+	return nil
+}
+
+// AddLabels mocks adding labels to a pull request.
+func (m *MockClient) AddLabels(ctx context.Context, owner, repo string, prNumber int, labels []string) error {
+	key := owner + "/" + repo + "/" + string(rune(prNumber))
+	if err, ok := m.AddLabelsErr[key]; ok {
+		return err
+	}
+	m.AddedLabels[key] = append(m.AddedLabels[key], labels...)
+	return nil
+}
+
+// RemoveLabel mocks removing a label from a pull request.
+func (m *MockClient) RemoveLabel(ctx context.Context, owner, repo string, prNumber int, label string) error {
+	key := owner + "/" + repo + "/" + string(rune(prNumber))
+	if err, ok := m.RemoveLabelErr[key]; ok {
+		return err
+	}
+	m.RemovedLabels[key] = append(m.RemovedLabels[key], label)
+	return nil
+}
+
 // MergePullRequest mocks merging a pull request.
 func (m *MockClient) MergePullRequest(ctx context.Context, owner, repo string, prNumber int) error {
 	key := owner + "/" + repo + "/" + string(rune(prNumber))
@@ -155,3 +273,8 @@ func (m *MockClient) DeleteBranch(ctx context.Context, owner, repo, branch strin
 	}
 	return nil
 }
+
+// AuthenticatedUser returns the mock login.
+func (m *MockClient) AuthenticatedUser(ctx context.Context) (string, error) {
+	return m.Login, m.LoginErr
+}
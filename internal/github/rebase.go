@@ -0,0 +1,205 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// RebaseStrategy brings a pull request branch up to date with its base branch.
+// Bot-owned branches must be updated by their bot so it keeps ownership of the
+// branch; everything else falls back to GitHub's update branch API.
+type RebaseStrategy interface {
+	// Name identifies the strategy in results, for example "dependabot".
+	Name() string
+
+	// Matches reports whether this strategy owns the pull request.
+	Matches(pr PullRequest) bool
+
+	// Action describes the request in the imperative, for example "post @dependabot rebase comment".
+	Action() string
+
+	// Completed describes the request after it succeeded.
+	Completed() string
+
+	// RequestRebase asks for the pull request branch to be updated.
+	RequestRebase(ctx context.Context, client Client, owner, repo string, pr PullRequest) error
+}
+
+// Recreator is implemented by rebase strategies whose bot can regenerate a
+// conflicted branch from scratch. A rebase cannot resolve conflicts; a
+// recreate discards the branch and opens it again against the base branch.
+type Recreator interface {
+	// RecreateAction describes the request in the imperative, for example "post @dependabot recreate comment".
+	RecreateAction() string
+
+	// RecreateCompleted describes the request after it succeeded.
+	RecreateCompleted() string
+
+	// RequestRecreate asks the bot to regenerate the pull request branch.
+	RequestRecreate(ctx context.Context, client Client, owner, repo string, pr PullRequest) error
+}
+
+// SelectRecreator returns the recreator for a pull request, or nil if its
+// branch is not owned by a bot that can regenerate it.
+func SelectRecreator(pr PullRequest) Recreator {
+	r, _ := SelectRebaseStrategy(pr).(Recreator)
+	return r
+}
+
+// Rebase strategy names.
+const (
+	RebaseStrategyDependabot   = "dependabot"
+	RebaseStrategyRenovate     = "renovate"
+	RebaseStrategyUpdateBranch = "update-branch"
+)
+
+// RebaseStrategies lists the bot strategies in match order. The update branch
+// strategy is not listed; it is used when none of these match.
+var RebaseStrategies = []RebaseStrategy{
+	DependabotRebaseStrategy{},
+	RenovateRebaseStrategy{},
+}
+
+// SelectRebaseStrategy returns the first strategy that owns the pull request,
+// falling back to the update branch API.
+func SelectRebaseStrategy(pr PullRequest) RebaseStrategy {
+	for _, s := range RebaseStrategies {
+		if s.Matches(pr) {
+			return s
+		}
+	}
+	return UpdateBranchRebaseStrategy{}
+}
+
+// LookupRebaseStrategy returns the strategy with the given name, or nil if there is none.
+func LookupRebaseStrategy(name string) RebaseStrategy {
+	if name == RebaseStrategyUpdateBranch {
+		return UpdateBranchRebaseStrategy{}
+	}
+	for _, s := range RebaseStrategies {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// DependabotRebaseStrategy asks Dependabot to rebase by posting a comment.
+type DependabotRebaseStrategy struct{}
+
+func (DependabotRebaseStrategy) Name() string { return RebaseStrategyDependabot }
+
+func (DependabotRebaseStrategy) Matches(pr PullRequest) bool {
+	return IsDependabotPullRequest(pr)
+}
+
+func (DependabotRebaseStrategy) Action() string { return "post @dependabot rebase comment" }
+
+func (DependabotRebaseStrategy) Completed() string { return "posted @dependabot rebase comment" }
+
+func (DependabotRebaseStrategy) RequestRebase(ctx context.Context, client Client, owner, repo string, pr PullRequest) error {
+	return client.PostRebaseComment(ctx, owner, repo, pr.Number)
+}
+
+func (DependabotRebaseStrategy) RecreateAction() string { return "post @dependabot recreate comment" }
+
+func (DependabotRebaseStrategy) RecreateCompleted() string {
+	return "posted @dependabot recreate comment"
+}
+
+func (DependabotRebaseStrategy) RequestRecreate(ctx context.Context, client Client, owner, repo string, pr PullRequest) error {
+	return client.PostComment(ctx, owner, repo, pr.Number, DependabotRecreateCommand)
+}
+
+// renovateAuthors are the logins Renovate opens pull requests as.
+var renovateAuthors = []string{"renovate[bot]", "renovate-bot", "forking-renovate[bot]"}
+
+var (
+	// renovateRebaseCheckboxRe matches the unticked rebase checkbox Renovate adds to its PR bodies.
+	renovateRebaseCheckboxRe = regexp.MustCompile(`- \[ \]( *<!-- *rebase-check *-->)`)
+	// renovateRebaseMarkerRe matches the marker of the rebase checkbox, ticked or not.
+	renovateRebaseMarkerRe = regexp.MustCompile(`<!-- *rebase-check *-->`)
+)
+
+// ErrRebaseAlreadyRequested is returned by RequestRebase when the rebase was
+// already requested and the bot has not acted on it yet, so nothing was done.
+var ErrRebaseAlreadyRequested = errors.New("rebase already requested")
+
+// RenovateRebaseStrategy asks Renovate to rebase by ticking the rebase
+// checkbox in the pull request body. Updating the branch directly would make
+// Renovate treat it as edited and stop maintaining it.
+type RenovateRebaseStrategy struct{}
+
+func (RenovateRebaseStrategy) Name() string { return RebaseStrategyRenovate }
+
+func (RenovateRebaseStrategy) Matches(pr PullRequest) bool {
+	return IsRenovateBranch(pr.HeadBranch) ||
+		slices.Contains(renovateAuthors, pr.Author) ||
+		renovateRebaseMarkerRe.MatchString(pr.Body)
+}
+
+func (RenovateRebaseStrategy) Action() string { return "tick Renovate rebase checkbox" }
+
+func (RenovateRebaseStrategy) Completed() string { return "ticked Renovate rebase checkbox" }
+
+// RequestRebase fetches the current body so edits made since the scan are kept.
+func (RenovateRebaseStrategy) RequestRebase(ctx context.Context, client Client, owner, repo string, pr PullRequest) error {
+	current, err := client.GetPullRequest(ctx, owner, repo, pr.Number)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("pull request #%d not found", pr.Number)
+	}
+
+	body, ok := TickRenovateRebaseCheckbox(current.Body)
+	if !ok {
+		if renovateRebaseMarkerRe.MatchString(current.Body) {
+			// Already ticked; Renovate has not picked it up yet.
+			return ErrRebaseAlreadyRequested
+		}
+		return fmt.Errorf("rebase checkbox not found in pull request body")
+	}
+	return client.UpdatePullRequestBody(ctx, owner, repo, pr.Number, body)
+}
+
+// Renovate's rebase checkbox reads "rebase/retry": for a conflicted branch,
+// Renovate discards it and regenerates the update.
+func (RenovateRebaseStrategy) RecreateAction() string { return "tick Renovate rebase/retry checkbox" }
+
+func (RenovateRebaseStrategy) RecreateCompleted() string {
+	return "ticked Renovate rebase/retry checkbox"
+}
+
+func (s RenovateRebaseStrategy) RequestRecreate(ctx context.Context, client Client, owner, repo string, pr PullRequest) error {
+	return s.RequestRebase(ctx, client, owner, repo, pr)
+}
+
+// TickRenovateRebaseCheckbox ticks the Renovate rebase checkbox in a pull
+// request body. It reports false if there is no unticked checkbox.
+func TickRenovateRebaseCheckbox(body string) (string, bool) {
+	loc := renovateRebaseCheckboxRe.FindStringSubmatchIndex(body)
+	if loc == nil {
+		return body, false
+	}
+	return body[:loc[0]] + "- [x]" + body[loc[2]:], true
+}
+
+// UpdateBranchRebaseStrategy merges the base branch into the pull request
+// branch using GitHub's update branch API.
+type UpdateBranchRebaseStrategy struct{}
+
+func (UpdateBranchRebaseStrategy) Name() string { return RebaseStrategyUpdateBranch }
+
+func (UpdateBranchRebaseStrategy) Matches(pr PullRequest) bool { return true }
+
+func (UpdateBranchRebaseStrategy) Action() string { return "update branch via API" }
+
+func (UpdateBranchRebaseStrategy) Completed() string { return "branch update requested via API" }
+
+func (UpdateBranchRebaseStrategy) RequestRebase(ctx context.Context, client Client, owner, repo string, pr PullRequest) error {
+	return client.UpdateBranch(ctx, owner, repo, pr.Number)
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSelectRebaseStrategy(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PullRequest
+		want string
+	}{
+		{
+			name: "dependabot branch",
+			pr:   PullRequest{HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.21"},
+			want: RebaseStrategyDependabot,
+		},
+		{
+			name: "dependabot author",
+			pr:   PullRequest{HeadBranch: "deps/lodash", Author: "dependabot[bot]"},
+			want: RebaseStrategyDependabot,
+		},
+		{
+			name: "renovate branch",
+			pr:   PullRequest{HeadBranch: "renovate/lodash-4.x"},
+			want: RebaseStrategyRenovate,
+		},
+		{
+			name: "renovate author",
+			pr:   PullRequest{HeadBranch: "deps/lodash", Author: "renovate[bot]"},
+			want: RebaseStrategyRenovate,
+		},
+		{
+			name: "renovate body marker",
+			pr:   PullRequest{HeadBranch: "deps/lodash", Body: "- [ ] <!-- rebase-check -->If you want to rebase/retry this PR, check this box"},
+			want: RebaseStrategyRenovate,
+		},
+		{
+			name: "renovate body marker with spaces",
+			pr:   PullRequest{HeadBranch: "deps/lodash", Body: "- [ ] <!--rebase-check-->If you want to rebase/retry this PR, check this box"},
+			want: RebaseStrategyRenovate,
+		},
+		{
+			name: "regular branch",
+			pr:   PullRequest{HeadBranch: "feature/batch-update", Author: "octocat"},
+			want: RebaseStrategyUpdateBranch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectRebaseStrategy(tt.pr).Name(); got != tt.want {
+				t.Errorf("SelectRebaseStrategy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupRebaseStrategy(t *testing.T) {
+	for _, name := range []string{RebaseStrategyDependabot, RebaseStrategyRenovate, RebaseStrategyUpdateBranch} {
+		s := LookupRebaseStrategy(name)
+		if s == nil || s.Name() != name {
+			t.Errorf("LookupRebaseStrategy(%q) = %v, want strategy named %q", name, s, name)
+		}
+	}
+	if s := LookupRebaseStrategy(""); s != nil {
+		t.Errorf("LookupRebaseStrategy(\"\") = %v, want nil", s)
+	}
+}
+
+func TestTickRenovateRebaseCheckbox(t *testing.T) {
+	body := "Details\n\n---\n\n - [ ] <!-- rebase-check -->If you want to rebase/retry this PR, check this box\n"
+	got, ok := TickRenovateRebaseCheckbox(body)
+	if !ok {
+		t.Fatal("TickRenovateRebaseCheckbox() ok = false, want true")
+	}
+	want := "Details\n\n---\n\n - [x] <!-- rebase-check -->If you want to rebase/retry this PR, check this box\n"
+	if got != want {
+		t.Errorf("TickRenovateRebaseCheckbox() = %q, want %q", got, want)
+	}
+
+	if _, ok := TickRenovateRebaseCheckbox(want); ok {
+		t.Error("TickRenovateRebaseCheckbox() on ticked body ok = true, want false")
+	}
+}
+
+func TestRenovateRebaseStrategyRequestRebase(t *testing.T) {
+	mock := NewMockClient()
+	mock.PullRequests["testorg/repo1"] = []PullRequest{
+		{Number: 1, HeadBranch: "renovate/lodash-4.x", Body: "- [ ] <!-- rebase-check -->rebase"},
+		{Number: 2, HeadBranch: "renovate/react-18.x", Body: "- [x] <!-- rebase-check -->rebase"},
+		{Number: 3, HeadBranch: "renovate/vue-3.x", Body: "no checkbox"},
+	}
+
+	s := RenovateRebaseStrategy{}
+	if err := s.RequestRebase(context.Background(), mock, "testorg", "repo1", PullRequest{Number: 1}); err != nil {
+		t.Fatalf("RequestRebase(#1) error = %v", err)
+	}
+	if got := mock.UpdatedBodies["testorg/repo1/"+string(rune(1))]; got != "- [x] <!-- rebase-check -->rebase" {
+		t.Errorf("updated body = %q, want ticked checkbox", got)
+	}
+
+	if err := s.RequestRebase(context.Background(), mock, "testorg", "repo1", PullRequest{Number: 2}); !errors.Is(err, ErrRebaseAlreadyRequested) {
+		t.Fatalf("RequestRebase(#2) error = %v, want %v", err, ErrRebaseAlreadyRequested)
+	}
+	if err := s.RequestRebase(context.Background(), mock, "testorg", "repo1", PullRequest{Number: 3}); err == nil {
+		t.Error("RequestRebase(#3) error = nil, want missing checkbox error")
+	}
+	if len(mock.UpdateBodyCalls) != 1 {
+		t.Errorf("UpdatePullRequestBody called %d times, want 1", len(mock.UpdateBodyCalls))
+	}
+	if len(mock.UpdateBranchCalls) != 0 {
+		t.Errorf("UpdateBranch called %d times, want 0", len(mock.UpdateBranchCalls))
+	}
+}
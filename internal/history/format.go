@@ -0,0 +1,112 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+func init() {
+	output.RegisterSchema("history", "ghprmerge history trends", reflect.TypeFor[Trends]())
+}
+
+// WriteText writes the trends as plain text tables.
+func WriteText(w io.Writer, trends Trends) error {
+	if trends.Runs == 0 {
+		_, err := fmt.Fprintln(w, "No recorded runs found.")
+		return err
+	}
+	fmt.Fprintf(w, "History: %d runs from %s to %s\n", trends.Runs,
+		trends.From.UTC().Format(time.DateOnly), trends.To.UTC().Format(time.DateOnly))
+
+	fmt.Fprintln(w, "\nPer week:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  WEEK OF\tRUNS\tMERGED\tDEPENDABOT\tMEDIAN OPEN")
+	for _, week := range trends.Weeks {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%s\n", week.Start.Format(time.DateOnly), week.Runs, week.Merged,
+			week.DependabotMerged, formatHours(week.MedianOpenHours))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if trends.DependabotMerged > 0 {
+		fmt.Fprintf(w, "\nMedian time a Dependabot PR stayed open: %s (%d merged)\n",
+			formatHours(trends.DependabotMedianOpenHours), trends.DependabotMerged)
+	}
+
+	fmt.Fprintf(w, "\nRepositories failing checks or conflicting in %d or more runs:\n", RepeatThreshold)
+	if len(trends.ProblemRepos) == 0 {
+		fmt.Fprintln(w, "  none")
+	} else {
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  REPOSITORY\tCHECKS FAILING\tCONFLICT\tLAST SEEN")
+		for _, repo := range trends.ProblemRepos {
+			fmt.Fprintf(tw, "  %s\t%d\t%d\t%s\n", repo.Repository, repo.ChecksFailing, repo.Conflicts,
+				repo.LastSeen.UTC().Format(time.DateOnly))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "\nSkip reasons per week:")
+	for _, week := range trends.Weeks {
+		fmt.Fprintf(w, "  %s  %s\n", week.Start.Format(time.DateOnly), formatReasons(week.SkippedByReason))
+	}
+	return nil
+}
+
+// WriteJSON writes the trends as JSON.
+func WriteJSON(w io.Writer, trends Trends) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(trends)
+}
+
+// formatHours renders a duration in hours as days and hours, or "-" for none.
+func formatHours(hours float64) string {
+	if hours <= 0 {
+		return "-"
+	}
+	d := time.Duration(hours * float64(time.Hour)).Round(time.Minute)
+	days := int(d / (24 * time.Hour))
+	d -= time.Duration(days) * 24 * time.Hour
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, int(d/time.Hour))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
+
+// formatReasons lists skip reasons by count, most frequent first.
+func formatReasons(reasons map[string]int) string {
+	if len(reasons) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(reasons))
+	for name := range reasons {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if reasons[names[i]] != reasons[names[j]] {
+			return reasons[names[i]] > reasons[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s (%d)", name, reasons[name])
+	}
+	return strings.Join(parts, ", ")
+}
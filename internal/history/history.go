@@ -0,0 +1,110 @@
+// Package history keeps the results of past runs in a local JSONL file and
+// derives trends from them, such as merges per week and recurring failures.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// Off disables recording when given as the history file.
+const Off = "off"
+
+// Run is one recorded run: the command and its complete result.
+type Run struct {
+	Command string           `json:"command"`
+	Result  output.RunResult `json:"result"`
+}
+
+// Time returns when the run finished, or started when it has no end time.
+func (r Run) Time() time.Time {
+	if !r.Result.Metadata.EndTime.IsZero() {
+		return r.Result.Metadata.EndTime
+	}
+	return r.Result.Metadata.StartTime
+}
+
+// DefaultPath returns the history file used when --history-file is not given:
+// GHPRMERGE_HISTORY_FILE, or history.jsonl in the user's ghprmerge config
+// directory. Without either it returns an error rather than a path in the
+// working directory.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("GHPRMERGE_HISTORY_FILE"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no default history file location (%w); set --history-file or GHPRMERGE_HISTORY_FILE", err)
+	}
+	return filepath.Join(dir, "ghprmerge", "history.jsonl"), nil
+}
+
+// Record appends a run to the history file at path, creating it and its
+// directory when missing.
+func Record(path string, run Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	_, err = file.Write(append(data, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+	return nil
+}
+
+// Read returns the runs in the history file at path that finished at or after
+// since and, when org is set, ran against that organization. A missing file
+// has no runs.
+func Read(path, org string, since time.Time) ([]Run, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	var runs []Run
+	scanner := bufio.NewScanner(file)
+	// A run of a large organization is a single long line.
+	scanner.Buffer(make([]byte, 1024*1024), 256*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid history entry: %w", path, line, err)
+		}
+		if org != "" && run.Result.Metadata.Org != org {
+			continue
+		}
+		if !since.IsZero() && run.Time().Before(since) {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,75 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+func TestRecordRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "history.jsonl")
+	day := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	runs := []Run{
+		{Command: "merge", Result: output.RunResult{Metadata: output.RunMetadata{Org: "myorg", EndTime: day}}},
+		{Command: "close", Result: output.RunResult{Metadata: output.RunMetadata{Org: "other", EndTime: day.AddDate(0, 0, 1)}}},
+		{Command: "merge", Result: output.RunResult{Metadata: output.RunMetadata{Org: "myorg", StartTime: day.AddDate(0, 0, 7)}}},
+	}
+	for _, run := range runs {
+		if err := Record(path, run); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("history file mode = %v, want 0600", perm)
+	}
+
+	tests := []struct {
+		name  string
+		org   string
+		since time.Time
+		want  []string
+	}{
+		{"all", "", time.Time{}, []string{"merge", "close", "merge"}},
+		{"org", "myorg", time.Time{}, []string{"merge", "merge"}},
+		{"since uses start time without end time", "", day.AddDate(0, 0, 2), []string{"merge"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(path, tt.org, tt.since)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Read() returned %d runs, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].Command != tt.want[i] {
+					t.Errorf("run %d command = %q, want %q", i, got[i].Command, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadMissingAndInvalid(t *testing.T) {
+	dir := t.TempDir()
+	runs, err := Read(filepath.Join(dir, "missing.jsonl"), "", time.Time{})
+	if err != nil || runs != nil {
+		t.Errorf("Read(missing) = %v, %v, want no runs", runs, err)
+	}
+
+	path := filepath.Join(dir, "history.jsonl")
+	if err := os.WriteFile(path, []byte("{\"command\":\"merge\"}\nnot json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path, "", time.Time{}); err == nil {
+		t.Error("Read() accepted an invalid line")
+	}
+}
@@ -0,0 +1,166 @@
+package history
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// RepeatThreshold is the number of runs in which a repository must have failing
+// checks or a conflict to be listed as a repeat offender.
+const RepeatThreshold = 2
+
+// Week aggregates the runs that finished in one week, starting on Monday (UTC).
+type Week struct {
+	Start            time.Time      `json:"start"`
+	Runs             int            `json:"runs"`
+	Merged           int            `json:"merged"`
+	DependabotMerged int            `json:"dependabot_merged"`
+	MedianOpenHours  float64        `json:"median_open_hours,omitempty"`
+	SkippedByReason  map[string]int `json:"skipped_by_reason,omitempty"`
+}
+
+// ProblemRepo is a repository whose PRs repeatedly failed checks or conflicted.
+type ProblemRepo struct {
+	Repository    string    `json:"repository"`
+	ChecksFailing int       `json:"checks_failing_runs"`
+	Conflicts     int       `json:"conflict_runs"`
+	LastSeen      time.Time `json:"last_seen"`
+}
+
+// Trends summarizes a set of recorded runs. It is the document written by
+// history --json.
+type Trends struct {
+	SchemaVersion             int           `json:"schema_version"`
+	Runs                      int           `json:"runs"`
+	From                      time.Time     `json:"from,omitzero"`
+	To                        time.Time     `json:"to,omitzero"`
+	Weeks                     []Week        `json:"weeks"`
+	DependabotMerged          int           `json:"dependabot_merged"`
+	DependabotMedianOpenHours float64       `json:"dependabot_median_open_hours,omitempty"`
+	ProblemRepos              []ProblemRepo `json:"problem_repos"`
+}
+
+// Compute derives trends from runs. The time a PR stayed open is measured from
+// its creation to the end of the run that merged it, so it only covers PRs
+// merged by ghprmerge whose creation time was recorded.
+func Compute(runs []Run) Trends {
+	trends := Trends{SchemaVersion: output.SchemaVersion, Runs: len(runs), Weeks: []Week{}, ProblemRepos: []ProblemRepo{}}
+	weeks := make(map[time.Time]*Week)
+	weekOpen := make(map[time.Time][]time.Duration)
+	var allOpen []time.Duration
+	problems := make(map[string]*ProblemRepo)
+
+	for _, run := range runs {
+		at := run.Time()
+		if trends.From.IsZero() || at.Before(trends.From) {
+			trends.From = at
+		}
+		if at.After(trends.To) {
+			trends.To = at
+		}
+
+		start := weekStart(at)
+		week := weeks[start]
+		if week == nil {
+			week = &Week{Start: start, SkippedByReason: make(map[string]int)}
+			weeks[start] = week
+		}
+		week.Runs++
+
+		for _, repo := range run.Result.Repositories {
+			var failing, conflict bool
+			for _, pr := range repo.PullRequests {
+				switch {
+				case pr.Action == output.ActionMerged:
+					week.Merged++
+					if !isDependabot(pr) {
+						continue
+					}
+					week.DependabotMerged++
+					trends.DependabotMerged++
+					if !pr.CreatedAt.IsZero() && at.After(pr.CreatedAt) {
+						open := at.Sub(pr.CreatedAt)
+						weekOpen[start] = append(weekOpen[start], open)
+						allOpen = append(allOpen, open)
+					}
+				case pr.SkipReason != "":
+					week.SkippedByReason[string(pr.SkipReason)]++
+					failing = failing || pr.SkipReason == output.ReasonChecksFailing
+					conflict = conflict || pr.SkipReason == output.ReasonConflict
+				}
+			}
+			if !failing && !conflict {
+				continue
+			}
+			problem := problems[repo.FullName]
+			if problem == nil {
+				problem = &ProblemRepo{Repository: repo.FullName}
+				problems[repo.FullName] = problem
+			}
+			if failing {
+				problem.ChecksFailing++
+			}
+			if conflict {
+				problem.Conflicts++
+			}
+			if at.After(problem.LastSeen) {
+				problem.LastSeen = at
+			}
+		}
+	}
+
+	for start, week := range weeks {
+		week.MedianOpenHours = median(weekOpen[start]).Hours()
+		trends.Weeks = append(trends.Weeks, *week)
+	}
+	sort.Slice(trends.Weeks, func(i, j int) bool {
+		return trends.Weeks[i].Start.Before(trends.Weeks[j].Start)
+	})
+	trends.DependabotMedianOpenHours = median(allOpen).Hours()
+
+	for _, problem := range problems {
+		if problem.ChecksFailing >= RepeatThreshold || problem.Conflicts >= RepeatThreshold {
+			trends.ProblemRepos = append(trends.ProblemRepos, *problem)
+		}
+	}
+	sort.Slice(trends.ProblemRepos, func(i, j int) bool {
+		a, b := trends.ProblemRepos[i], trends.ProblemRepos[j]
+		if a.ChecksFailing+a.Conflicts != b.ChecksFailing+b.Conflicts {
+			return a.ChecksFailing+a.Conflicts > b.ChecksFailing+b.Conflicts
+		}
+		return a.Repository < b.Repository
+	})
+	return trends
+}
+
+// weekStart returns midnight UTC on the Monday of the week containing t.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// isDependabot reports whether a PR was opened by Dependabot. Runs recorded
+// before results carried the flag are checked from their author and branch.
+func isDependabot(pr output.PullRequestResult) bool {
+	return pr.Dependabot || gh.IsDependabotPullRequest(gh.PullRequest{Author: pr.Author, HeadBranch: pr.HeadBranch})
+}
+
+// median returns the median duration, or 0 when there are none.
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return (sorted[mid-1] + sorted[mid]) / 2
+}
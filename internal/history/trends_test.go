@@ -0,0 +1,140 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+func run(at time.Time, repos ...output.RepositoryResult) Run {
+	return Run{Command: "merge", Result: output.RunResult{
+		Metadata:     output.RunMetadata{Org: "myorg", StartTime: at.Add(-time.Minute), EndTime: at},
+		Repositories: repos,
+	}}
+}
+
+func repo(name string, prs ...output.PullRequestResult) output.RepositoryResult {
+	return output.RepositoryResult{Name: name, FullName: "myorg/" + name, PullRequests: prs}
+}
+
+func merged(author string, openFor time.Duration, at time.Time) output.PullRequestResult {
+	return output.PullRequestResult{Action: output.ActionMerged, Author: author, CreatedAt: at.Add(-openFor)}
+}
+
+func skipped(reason output.SkipReason) output.PullRequestResult {
+	return output.PullRequestResult{Action: output.ActionSkipChecksFailing, SkipReason: reason}
+}
+
+func TestCompute(t *testing.T) {
+	// Wednesday and Friday of one week, and Monday of the next.
+	wed := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	fri := time.Date(2026, 3, 6, 9, 0, 0, 0, time.UTC)
+	mon := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+
+	runs := []Run{
+		run(wed,
+			repo("api", merged("dependabot[bot]", 10*time.Hour, wed), skipped(output.ReasonChecksFailing)),
+			repo("web", merged("alice", time.Hour, wed), skipped(output.ReasonConflict)),
+		),
+		run(fri,
+			repo("api", merged("dependabot[bot]", 30*time.Hour, fri), skipped(output.ReasonChecksFailing)),
+			repo("web", skipped(output.ReasonChecksFailing)),
+		),
+		run(mon,
+			repo("api", skipped(output.ReasonChecksFailing), skipped(output.ReasonBranchBehind)),
+			repo("docs", merged("dependabot[bot]", 2*time.Hour, mon)),
+		),
+	}
+
+	trends := Compute(runs)
+	if trends.Runs != 3 || !trends.From.Equal(wed) || !trends.To.Equal(mon) {
+		t.Errorf("Runs/From/To = %d %v %v", trends.Runs, trends.From, trends.To)
+	}
+	if len(trends.Weeks) != 2 {
+		t.Fatalf("Weeks = %+v, want 2", trends.Weeks)
+	}
+	first, second := trends.Weeks[0], trends.Weeks[1]
+	if want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC); !first.Start.Equal(want) {
+		t.Errorf("first week starts %v, want %v", first.Start, want)
+	}
+	if first.Runs != 2 || first.Merged != 3 || first.DependabotMerged != 2 || first.MedianOpenHours != 20 {
+		t.Errorf("first week = %+v", first)
+	}
+	if first.SkippedByReason[string(output.ReasonChecksFailing)] != 3 || first.SkippedByReason[string(output.ReasonConflict)] != 1 {
+		t.Errorf("first week skip reasons = %v", first.SkippedByReason)
+	}
+	if second.Runs != 1 || second.Merged != 1 || second.MedianOpenHours != 2 {
+		t.Errorf("second week = %+v", second)
+	}
+	if trends.DependabotMerged != 3 || trends.DependabotMedianOpenHours != 10 {
+		t.Errorf("Dependabot merged = %d, median = %v", trends.DependabotMerged, trends.DependabotMedianOpenHours)
+	}
+
+	// api failed checks in all three runs; web failed checks once and
+	// conflicted once, so it does not repeat either problem.
+	if len(trends.ProblemRepos) != 1 {
+		t.Fatalf("ProblemRepos = %+v, want only api", trends.ProblemRepos)
+	}
+	if got := trends.ProblemRepos[0]; got.Repository != "myorg/api" || got.ChecksFailing != 3 || !got.LastSeen.Equal(mon) {
+		t.Errorf("ProblemRepos[0] = %+v", got)
+	}
+}
+
+func TestComputeDependabot(t *testing.T) {
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	flagged := merged("", time.Hour, at)
+	flagged.Dependabot = true
+	branch := merged("", time.Hour, at)
+	branch.HeadBranch = "dependabot/npm_and_yarn/lodash-4.17.21"
+
+	trends := Compute([]Run{run(at, repo("api", flagged, branch, merged("renovate[bot]", time.Hour, at)))})
+	if trends.DependabotMerged != 2 {
+		t.Errorf("DependabotMerged = %d, want 2", trends.DependabotMerged)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, Compute(nil)); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("WriteJSON() is not valid JSON: %v", err)
+	}
+	if doc["schema_version"] != float64(output.SchemaVersion) {
+		t.Errorf("schema_version = %v, want %d", doc["schema_version"], output.SchemaVersion)
+	}
+	if _, err := output.JSONSchema("history", output.CasingCamel); err != nil {
+		t.Errorf("JSONSchema(history) error = %v", err)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteText(&buf, Compute(nil)); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No recorded runs") {
+		t.Errorf("empty history output = %q", buf.String())
+	}
+
+	at := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	trends := Compute([]Run{
+		run(at, repo("api", merged("dependabot[bot]", 52*time.Hour, at), skipped(output.ReasonChecksFailing))),
+		run(at.Add(time.Hour), repo("api", skipped(output.ReasonChecksFailing))),
+	})
+	buf.Reset()
+	if err := WriteText(&buf, trends); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	for _, want := range []string{"2 runs from 2026-03-04", "2026-03-02", "2d 4h", "myorg/api", "checks failing (2)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
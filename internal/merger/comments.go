@@ -0,0 +1,100 @@
+package merger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// StatusCommentMarker is the hidden marker that identifies the status comment
+// kept by --comment-on-skip, so later runs update it instead of posting again.
+const StatusCommentMarker = "<!-- ghprmerge:status -->"
+
+// commentedActions are the outcomes that the PR author can act on. Pending
+// checks and other transient skips are left alone to avoid comment churn.
+var commentedActions = map[output.Action]bool{
+	output.ActionSkipConflict:      true,
+	output.ActionSkipChecksFailing: true,
+	output.ActionSkipBranchBehind:  true,
+	output.ActionMergeFailed:       true,
+	output.ActionRebaseFailed:      true,
+	output.ActionRecreateFailed:    true,
+}
+
+// statusCommentBody renders the status comment for a PR result.
+func statusCommentBody(pr *output.PullRequestResult) string {
+	var b strings.Builder
+	b.WriteString(StatusCommentMarker + "\n")
+	fmt.Fprintf(&b, "**ghprmerge** did not complete this pull request: `%s`.\n", pr.Action)
+	if pr.Reason != "" {
+		fmt.Fprintf(&b, "\n> %s\n", strings.ReplaceAll(pr.Reason, "\n", "\n> "))
+	}
+	b.WriteString("\n_This comment is updated on each ghprmerge run and removed once the pull request is merged._\n")
+	return b.String()
+}
+
+// syncStatusComment keeps a single ghprmerge status comment on the PR: it is
+// created or updated while the PR is skipped or failing and deleted once the PR
+// is merged. Comment failures are appended to the reason rather than changing
+// the action.
+func (m *Merger) syncStatusComment(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
+	if !m.config.CommentOnSkip {
+		return
+	}
+	commented := commentedActions[pr.Action]
+	if !commented && pr.Action != output.ActionMerged {
+		return
+	}
+
+	comments, err := m.client.ListComments(ctx, owner, repoName, pr.Number)
+	if err != nil {
+		pr.Reason += fmt.Sprintf(" (status comment failed: %s)", err)
+		return
+	}
+	login, err := m.tokenLogin(ctx)
+	if err != nil {
+		pr.Reason += fmt.Sprintf(" (status comment failed: %s)", err)
+		return
+	}
+	var existingID int64
+	var existingBody string
+	for _, c := range comments {
+		// Only our own comment counts; others may quote the marker
+		if c.Author == login && strings.HasPrefix(c.Body, StatusCommentMarker) {
+			existingID, existingBody = c.ID, c.Body
+			break
+		}
+	}
+
+	if !commented {
+		if existingID != 0 {
+			err = m.client.DeleteComment(ctx, owner, repoName, pr.Number, existingID)
+		}
+	} else {
+		body := statusCommentBody(pr)
+		switch {
+		case existingID == 0:
+			err = m.client.PostComment(ctx, owner, repoName, pr.Number, body)
+		case existingBody != body:
+			err = m.client.UpdateComment(ctx, owner, repoName, pr.Number, existingID, body)
+		}
+	}
+	if err != nil {
+		pr.Reason += fmt.Sprintf(" (status comment failed: %s)", err)
+	}
+}
+
+// tokenLogin looks up the login of the token owner once, so status comments
+// written by anyone else are never edited or deleted.
+func (m *Merger) tokenLogin(ctx context.Context) (string, error) {
+	if !m.loginDone {
+		m.login, m.loginErr = m.client.AuthenticatedUser(ctx)
+		if m.loginErr != nil {
+			m.loginErr = fmt.Errorf("failed to look up the token owner: %w", m.loginErr)
+		}
+		m.loginDone = true
+	}
+	return m.login, m.loginErr
+}
@@ -0,0 +1,47 @@
+package merger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// ExecutePullRequest executes the planned action of a single PR from a scan
+// result, as RunWithActions does for every PR. It is used by interactive
+// front ends that act on one PR at a time.
+func (m *Merger) ExecutePullRequest(ctx context.Context, repo output.RepositoryResult, pr *output.PullRequestResult) {
+	owner := strings.Split(repo.FullName, "/")[0]
+	m.executePending(ctx, owner, repo.Name, pr)
+}
+
+// RefreshPullRequest evaluates a single PR again against its current state on
+// GitHub without performing any mutations.
+func (m *Merger) RefreshPullRequest(ctx context.Context, repo output.RepositoryResult, number int) (output.PullRequestResult, error) {
+	owner := strings.Split(repo.FullName, "/")[0]
+	pr, err := m.client.GetPullRequest(ctx, owner, repo.Name, number)
+	if err != nil {
+		return output.PullRequestResult{}, err
+	}
+	if pr == nil {
+		return output.PullRequestResult{}, fmt.Errorf("pull request #%d not found", number)
+	}
+	if pr.State != "" && pr.State != "open" {
+		return output.PullRequestResult{}, fmt.Errorf("pull request #%d is %s", number, pr.State)
+	}
+
+	target := gh.Repository{Name: repo.Name, FullName: repo.FullName, DefaultBranch: repo.DefaultBranch}
+	return m.evaluatePullRequest(ctx, owner, target, *pr), nil
+}
+
+// Summarize recomputes the action counters of a result from its PR actions.
+func (m *Merger) Summarize(result *output.RunResult) {
+	resetActionCounts(&result.Summary)
+	for _, repo := range result.Repositories {
+		for _, pr := range repo.PullRequests {
+			m.updateSummary(&result.Summary, pr)
+		}
+	}
+}
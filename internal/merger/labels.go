@@ -0,0 +1,76 @@
+package merger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// SkipLabelPrefix prefixes every label applied by --label-skipped.
+const SkipLabelPrefix = "ghprmerge:"
+
+// skipLabels maps skip reasons to the label applied by --label-skipped. API
+// errors are not labeled because they rarely describe the pull request itself.
+var skipLabels = map[output.SkipReason]string{
+	output.ReasonConflict:      SkipLabelPrefix + "conflict",
+	output.ReasonChecksFailing: SkipLabelPrefix + "checks-failing",
+	output.ReasonChecksPending: SkipLabelPrefix + "checks-pending",
+	output.ReasonBranchBehind:  SkipLabelPrefix + "branch-behind",
+}
+
+// matchesLabelFilters reports whether a PR passes the --label and --exclude-label filters.
+func (m *Merger) matchesLabelFilters(pr gh.PullRequest) bool {
+	for _, label := range m.config.Labels {
+		if !pr.HasLabel(label) {
+			return false
+		}
+	}
+	for _, label := range m.config.ExcludeLabels {
+		if pr.HasLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// syncSkipLabel makes the PR's ghprmerge labels match its outcome: skipped PRs
+// get the label for their skip reason and stale ghprmerge labels are removed.
+// Label failures are appended to the reason rather than changing the action.
+// PRs skipped for API errors, deselected by the user, or interrupted are left
+// untouched.
+func (m *Merger) syncSkipLabel(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
+	if !m.config.LabelSkipped || pr.SkipReason == output.ReasonAPIError || pr.SkipReason == output.ReasonDeselected || pr.SkipReason == output.ReasonInterrupted {
+		return
+	}
+
+	want := skipLabels[pr.SkipReason]
+	var errs []string
+	var kept []string
+	for _, label := range pr.Labels {
+		if strings.HasPrefix(label, SkipLabelPrefix) && label != want {
+			if err := m.client.RemoveLabel(ctx, owner, repoName, pr.Number, label); err != nil {
+				errs = append(errs, err.Error())
+				kept = append(kept, label)
+			}
+			continue
+		}
+		kept = append(kept, label)
+	}
+
+	if want != "" && !slices.Contains(kept, want) {
+		if err := m.client.AddLabels(ctx, owner, repoName, pr.Number, []string{want}); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			kept = append(kept, want)
+		}
+	}
+
+	pr.Labels = kept
+	if len(errs) > 0 {
+		pr.Reason += fmt.Sprintf(" (label update failed: %s)", strings.Join(errs, "; "))
+	}
+}
@@ -3,6 +3,7 @@ package merger
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/config"
 	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/state"
 )
 
 // Merger handles the discovery, evaluation, and merging of pull requests.
@@ -18,6 +20,14 @@ type Merger struct {
 	client           gh.Client
 	config           *config.Config
 	console          *output.Console
+	events           *output.EventStream
+	state            *state.State
+	selection        map[string][]int
+	superseded       map[string]supersession
+	staleReasons     map[string]string
+	login            string
+	loginErr         error
+	loginDone        bool
 	scanDisplayLines int
 	lastMergeAttempt time.Time
 }
@@ -31,6 +41,26 @@ func New(client gh.Client, cfg *config.Config, console *output.Console) *Merger
 	}
 }
 
+// SetEventStream streams NDJSON events for each repository, PR decision, and
+// executed action as the run progresses.
+func (m *Merger) SetEventStream(events *output.EventStream) {
+	m.events = events
+}
+
+// SetState checkpoints the run to a state file. When the state was loaded from
+// an earlier run, repositories it completed are taken from the state instead of
+// being scanned again.
+func (m *Merger) SetState(st *state.State) {
+	m.state = st
+}
+
+// SetSelection restricts the run to exact pull request numbers, keyed by
+// repository name, such as the PRs of a saved report group. It replaces
+// source branch matching.
+func (m *Merger) SetSelection(selection map[string][]int) {
+	m.selection = selection
+}
+
 // Run executes the merger logic and returns the result.
 // Processing is strictly sequential: one repository at a time, one PR at a time.
 func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
@@ -48,6 +78,9 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 
 	// Build source branch description
 	sourceBranchDesc := strings.Join(m.config.SourceBranches, ", ")
+	if m.selection != nil {
+		sourceBranchDesc = strings.Join(m.config.ReportGroups, ", ")
+	}
 
 	result := &output.RunResult{
 		Metadata: output.RunMetadata{
@@ -67,6 +100,8 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 		},
 	}
 
+	m.events.RunStarted(result.Metadata)
+
 	// Discover repositories
 	repos, err := m.discoverRepositories(ctx)
 	if err != nil {
@@ -86,6 +121,32 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 
 	// Process each repository sequentially
 	for i, repo := range repos {
+		// After an interrupt, record the remaining repositories without scanning them
+		if ctx.Err() != nil {
+			repoResult := interruptedRepository(repo)
+			result.Repositories = append(result.Repositories, repoResult)
+			result.Summary.ReposSkipped++
+			result.Metadata.Interrupted = true
+			m.events.RepositoryScanned(repoResult)
+			continue
+		}
+
+		// Repositories completed by the run being resumed keep their recorded result
+		if prior, ok := m.state.Completed(repo.FullName); ok {
+			result.Repositories = append(result.Repositories, prior)
+			result.Summary.ReposProcessed++
+			result.Metadata.Resumed = true
+			for _, pr := range prior.PullRequests {
+				result.Summary.CandidatesFound++
+				m.updateSummary(&result.Summary, pr)
+			}
+			m.events.RepositoryScanned(prior)
+			continue
+		}
+		if len(m.state.PullRequests(repo.FullName)) > 0 {
+			result.Metadata.Resumed = true
+		}
+
 		// Update progress bar
 		if showProgress {
 			m.console.ProgressBar(i+1, len(repos), "Scanning")
@@ -104,12 +165,18 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 			result.Repositories = append(result.Repositories, repoResult)
 			result.Summary.ReposSkipped++
 		} else {
-			if m.config.Confirm {
+			if m.config.Confirm || m.config.TUI {
 				repoResult = m.processRepositoryScanOnly(ctx, repo)
 			} else {
 				repoResult = m.processRepository(ctx, repo)
 			}
 			result.Repositories = append(result.Repositories, repoResult)
+			if isInterrupted(repoResult) {
+				result.Metadata.Interrupted = true
+			}
+			if !m.config.Confirm && !m.config.TUI && isComplete(repoResult) {
+				m.state.CompleteRepository(repoResult)
+			}
 
 			if repoResult.Skipped {
 				result.Summary.ReposSkipped++
@@ -124,6 +191,7 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 				m.updateSummary(&result.Summary, pr)
 			}
 		}
+		m.events.RepositoryScanned(repoResult)
 
 		if showProgress && (m.shouldStreamScanResults() || hasCompletedActions(repoResult)) {
 			m.scanDisplayLines += m.printRepoResultWithProgress(repoResult, i+1, len(repos), "Scanning")
@@ -142,8 +210,9 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 	// Repos with completed actions (merge/rebase) were already streamed during the scan
 	// and are excluded to avoid duplicate output.
 	// Confirm mode still needs this fallback when there is nothing to confirm so the
-	// user can see which repos matched and why they were skipped.
-	if m.console != nil && !m.config.JSON && !m.config.Verbose && (!m.config.Confirm || !hasPendingActions(result)) {
+	// user can see which repos matched and why they were skipped. The dashboard shows
+	// every result itself.
+	if m.console != nil && !m.config.JSON && !m.config.Verbose && !m.config.TUI && (!m.config.Confirm || !hasPendingActions(result)) {
 		var toPrint []output.RepositoryResult
 		for _, repo := range result.Repositories {
 			if len(repo.PullRequests) > 0 && !hasCompletedActions(repo) {
@@ -165,24 +234,13 @@ func (m *Merger) Run(ctx context.Context) (*output.RunResult, error) {
 
 // RunWithActions executes actions on a previously scanned result (used with --confirm).
 func (m *Merger) RunWithActions(ctx context.Context, scanResult *output.RunResult) (*output.RunResult, error) {
-	// Reset summary counters that will be updated
-	scanResult.Summary.MergedSuccess = 0
-	scanResult.Summary.MergeFailed = 0
-	scanResult.Summary.RebasedSuccess = 0
-	scanResult.Summary.RebaseFailed = 0
-	scanResult.Summary.ClosedSuccess = 0
-	scanResult.Summary.CloseFailed = 0
-	scanResult.Summary.WouldMerge = 0
-	scanResult.Summary.WouldRebase = 0
-	scanResult.Summary.WouldClose = 0
-	scanResult.Summary.Skipped = 0
-	scanResult.Summary.SkippedByReason = make(map[string]int)
+	resetActionCounts(&scanResult.Summary)
 
 	// Count total actions for progress bar
 	totalActions := 0
 	for _, repo := range scanResult.Repositories {
 		for _, pr := range repo.PullRequests {
-			if pr.Action == output.ActionWouldRebase || pr.Action == output.ActionWouldMerge || pr.Action == output.ActionWouldClose {
+			if output.IsPendingAction(pr.Action) {
 				totalActions++
 			}
 		}
@@ -203,32 +261,38 @@ func (m *Merger) RunWithActions(ctx context.Context, scanResult *output.RunResul
 		for j := range repo.PullRequests {
 			pr := &repo.PullRequests[j]
 
-			// Execute actions based on what was planned
-			switch pr.Action {
-			case output.ActionWouldRebase:
-				actionNum++
-				if showProgress {
-					m.console.ProgressBar(actionNum, totalActions, "Executing")
+			// After an interrupt, start no new calls; planned actions are recorded as interrupted
+			if ctx.Err() != nil {
+				if output.IsPendingAction(pr.Action) {
+					interruptPullRequest(pr, fmt.Sprintf("run interrupted before %q was executed", pr.Action))
+					scanResult.Metadata.Interrupted = true
 				}
-				m.executeRebase(ctx, owner, repo.Name, pr)
-			case output.ActionWouldMerge:
-				actionNum++
-				if showProgress {
-					m.console.ProgressBar(actionNum, totalActions, "Executing")
-				}
-				m.executeMerge(ctx, owner, repo.Name, pr)
-			case output.ActionWouldClose:
+				m.updateSummary(&scanResult.Summary, *pr)
+				continue
+			}
+
+			// Execute actions based on what was planned
+			pending := output.IsPendingAction(pr.Action)
+			if pending {
 				actionNum++
 				if showProgress {
 					m.console.ProgressBar(actionNum, totalActions, "Executing")
 				}
-				m.executeClose(ctx, owner, repo.Name, pr)
+				m.state.StartPullRequest(repo.FullName, pr.Number)
+			}
+			m.executePending(context.WithoutCancel(ctx), owner, repo.Name, pr)
+			if pending {
+				m.state.FinishPullRequest(repo.FullName, *pr)
 			}
 
 			// Update summary
 			m.updateSummary(&scanResult.Summary, *pr)
 		}
 
+		if isComplete(*repo) {
+			m.state.CompleteRepository(*repo)
+		}
+
 		if showProgress && hasCompletedActions(*repo) {
 			m.printRepoResultWithProgress(*repo, actionNum, totalActions, "Executing")
 		}
@@ -253,30 +317,76 @@ func (m *Merger) RunWithActions(ctx context.Context, scanResult *output.RunResul
 	return scanResult, nil
 }
 
+// executePending executes the planned action of a PR from a scan result and
+// then syncs its skip label and status comment.
+func (m *Merger) executePending(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
+	pending := output.IsPendingAction(pr.Action)
+	switch pr.Action {
+	case output.ActionWouldRebase:
+		m.executeRebase(ctx, owner, repoName, pr)
+	case output.ActionWouldMerge:
+		m.executeMerge(ctx, owner, repoName, pr)
+	case output.ActionWouldClose, output.ActionWouldIgnore:
+		m.executeClose(ctx, owner, repoName, pr)
+	case output.ActionWouldRecreate:
+		m.executeRecreate(ctx, owner, repoName, pr)
+	}
+	m.syncSkipLabel(ctx, owner, repoName, pr)
+	m.syncStatusComment(ctx, owner, repoName, pr)
+	if pending {
+		m.events.ActionExecuted(owner+"/"+repoName, *pr)
+	}
+}
+
+// resetActionCounts clears the summary counters derived from PR actions.
+func resetActionCounts(summary *output.RunSummary) {
+	summary.MergedSuccess = 0
+	summary.MergeFailed = 0
+	summary.RebasedSuccess = 0
+	summary.RebaseFailed = 0
+	summary.ClosedSuccess = 0
+	summary.CloseFailed = 0
+	summary.WouldMerge = 0
+	summary.WouldRebase = 0
+	summary.WouldClose = 0
+	summary.RecreateRequested = 0
+	summary.RecreateFailed = 0
+	summary.WouldRecreate = 0
+	summary.IgnoredSuccess = 0
+	summary.IgnoreFailed = 0
+	summary.WouldIgnore = 0
+	summary.ReadyToMerge = 0
+	summary.Skipped = 0
+	summary.SkippedByReason = make(map[string]int)
+}
+
 // ScanDisplayLines returns the number of scan-time terminal lines written for live verbose output.
 func (m *Merger) ScanDisplayLines() int {
 	return m.scanDisplayLines
 }
 
-// executeRebase executes a rebase action on a PR.
+// executeRebase executes a rebase action on a PR using the strategy chosen during the scan.
 func (m *Merger) executeRebase(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
-	if gh.IsDependabotBranch(pr.HeadBranch) {
-		if err := m.client.PostRebaseComment(ctx, owner, repoName, pr.Number); err != nil {
-			pr.Action = output.ActionRebaseFailed
-			pr.Reason = fmt.Sprintf("failed to post rebase comment: %v", err)
-		} else {
-			pr.Action = output.ActionRebased
-			pr.Reason = "posted @dependabot rebase comment"
-		}
-	} else {
-		if err := m.client.UpdateBranch(ctx, owner, repoName, pr.Number); err != nil {
-			pr.Action = output.ActionRebaseFailed
-			pr.Reason = fmt.Sprintf("failed to update branch: %v", err)
-		} else {
-			pr.Action = output.ActionRebased
-			pr.Reason = "branch update requested via API"
-		}
+	target := pullRequestFromResult(pr)
+	strategy := gh.LookupRebaseStrategy(pr.RebaseStrategy)
+	if strategy == nil {
+		strategy = gh.SelectRebaseStrategy(target)
+	}
+	pr.RebaseStrategy = strategy.Name()
+
+	err := strategy.RequestRebase(ctx, m.client, owner, repoName, target)
+	if errors.Is(err, gh.ErrRebaseAlreadyRequested) {
+		pr.Action = output.ActionRebased
+		pr.Reason = err.Error()
+		return
 	}
+	if err != nil {
+		pr.Action = output.ActionRebaseFailed
+		pr.Reason = fmt.Sprintf("failed to %s: %v", strategy.Action(), err)
+		return
+	}
+	pr.Action = output.ActionRebased
+	pr.Reason = strategy.Completed()
 }
 
 // executeMerge executes a merge action on a PR.
@@ -291,32 +401,148 @@ func (m *Merger) executeMerge(ctx context.Context, owner, repoName string, pr *o
 }
 
 // executeClose closes a PR and optionally deletes its source branch.
+// With --dependabot-ignore, Dependabot PRs are first told to ignore the update;
+// with --superseded, a comment first names the PR that supersedes it, and
+// with --stale or --close-comment, the closing comment is posted first.
 func (m *Merger) executeClose(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
+	closed, failed := output.ActionClosed, output.ActionCloseFailed
+	command, err := m.dependabotIgnoreCommand(pullRequestFromResult(pr))
+	if err != nil {
+		pr.Action = output.ActionIgnoreFailed
+		pr.Reason = err.Error()
+		return
+	}
+	prefix := ""
+	if s, ok := m.superseded[prKey(owner+"/"+repoName, pr.Number)]; ok {
+		if err := m.client.PostComment(ctx, owner, repoName, pr.Number, s.comment()); err != nil {
+			pr.Action = failed
+			pr.Reason = fmt.Sprintf("superseded by #%d, but failed to post comment: %v", s.newer, err)
+			return
+		}
+		prefix = fmt.Sprintf("superseded by #%d, ", s.newer)
+	}
+	if reason, ok := m.staleReasons[prKey(owner+"/"+repoName, pr.Number)]; ok {
+		prefix = "stale (" + reason + "), "
+	}
+	if comment := m.closeComment(owner+"/"+repoName, pr.Number); comment != "" {
+		if err := m.client.PostComment(ctx, owner, repoName, pr.Number, comment); err != nil {
+			pr.Action = failed
+			pr.Reason = fmt.Sprintf("%sfailed to post closing comment: %v", prefix, err)
+			return
+		}
+	}
+	if command != "" {
+		closed, failed = output.ActionIgnored, output.ActionIgnoreFailed
+		if err := m.client.PostComment(ctx, owner, repoName, pr.Number, command); err != nil {
+			pr.Action = failed
+			pr.Reason = fmt.Sprintf("%sfailed to post %s comment: %v", prefix, command, err)
+			return
+		}
+		prefix += "posted " + command + ", "
+	}
+
 	if err := m.client.ClosePullRequest(ctx, owner, repoName, pr.Number); err != nil {
-		pr.Action = output.ActionCloseFailed
-		pr.Reason = fmt.Sprintf("close failed: %v", err)
+		pr.Action = failed
+		pr.Reason = fmt.Sprintf("%sclose failed: %v", prefix, err)
 		return
 	}
 
-	pr.Action = output.ActionClosed
-	pr.Reason = "successfully closed"
+	pr.Action = closed
+	pr.Reason = prefix + "successfully closed"
 	if !m.config.DeleteSourceBranch {
 		return
 	}
 
 	parts := strings.SplitN(pr.HeadRepoFullName, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		pr.Action = output.ActionCloseFailed
-		pr.Reason = fmt.Sprintf("closed, but could not determine source repository %q for branch deletion", pr.HeadRepoFullName)
+		pr.Action = failed
+		pr.Reason = fmt.Sprintf("%sclosed, but could not determine source repository %q for branch deletion", prefix, pr.HeadRepoFullName)
 		return
 	}
 	branchOwner, branchRepo := parts[0], parts[1]
 	if err := m.client.DeleteBranch(ctx, branchOwner, branchRepo, pr.HeadBranch); err != nil {
-		pr.Action = output.ActionCloseFailed
-		pr.Reason = fmt.Sprintf("closed, but source branch deletion failed: %v", err)
+		pr.Action = failed
+		pr.Reason = fmt.Sprintf("%sclosed, but source branch deletion failed: %v", prefix, err)
 		return
 	}
-	pr.Reason = "successfully closed and source branch deleted"
+	pr.Reason = prefix + "successfully closed and source branch deleted"
+}
+
+// executeRecreate asks the bot that owns a conflicted PR to regenerate its branch.
+func (m *Merger) executeRecreate(ctx context.Context, owner, repoName string, pr *output.PullRequestResult) {
+	target := pullRequestFromResult(pr)
+	strategy := gh.LookupRebaseStrategy(pr.RebaseStrategy)
+	if strategy == nil {
+		strategy = gh.SelectRebaseStrategy(target)
+	}
+	recreator, ok := strategy.(gh.Recreator)
+	if !ok {
+		pr.Action = output.ActionRecreateFailed
+		pr.Reason = "pull request branch is not owned by a bot that can recreate it"
+		return
+	}
+	pr.RebaseStrategy = strategy.Name()
+
+	err := recreator.RequestRecreate(ctx, m.client, owner, repoName, target)
+	if errors.Is(err, gh.ErrRebaseAlreadyRequested) {
+		pr.Action = output.ActionRecreateRequested
+		pr.Reason = err.Error() + " (pull request has merge conflicts)"
+		return
+	}
+	if err != nil {
+		pr.Action = output.ActionRecreateFailed
+		pr.Reason = fmt.Sprintf("failed to %s: %v", recreator.RecreateAction(), err)
+		return
+	}
+	pr.Action = output.ActionRecreateRequested
+	pr.Reason = recreator.RecreateCompleted() + " (pull request has merge conflicts)"
+}
+
+// dependabotIgnoreCommand returns the ignore comment to post before closing the
+// PR, or "" when --dependabot-ignore is unset or the PR is not from Dependabot.
+func (m *Merger) dependabotIgnoreCommand(pr gh.PullRequest) (string, error) {
+	if m.config.DependabotIgnore == "" || !gh.IsDependabotPullRequest(pr) {
+		return "", nil
+	}
+	return gh.DependabotIgnoreCommand(m.config.DependabotIgnore)
+}
+
+// canRecreate reports whether a conflicted PR should be recreated by its bot instead of skipped.
+func (m *Merger) canRecreate(pr gh.PullRequest) bool {
+	return m.config.RecreateConflicted && (m.config.Rebase || m.config.Merge) && gh.SelectRecreator(pr) != nil
+}
+
+// newPullRequestResult starts a result carrying the PR fields that output and
+// the execution phase of --confirm rely on.
+func newPullRequestResult(pr gh.PullRequest) output.PullRequestResult {
+	return output.PullRequestResult{
+		Number:           pr.Number,
+		URL:              pr.URL,
+		HeadBranch:       pr.HeadBranch,
+		Title:            pr.Title,
+		HeadRepoFullName: pr.HeadRepoFullName,
+		Author:           pr.Author,
+		Dependabot:       gh.IsDependabotPullRequest(pr),
+		Labels:           pr.Labels,
+		CreatedAt:        pr.CreatedAt,
+		UpdatedAt:        pr.UpdatedAt,
+	}
+}
+
+// pullRequestFromResult rebuilds the PR fields carried on a result for use in
+// the execution phase of --confirm, where only the scan result is available.
+func pullRequestFromResult(pr *output.PullRequestResult) gh.PullRequest {
+	return gh.PullRequest{
+		Number:           pr.Number,
+		Title:            pr.Title,
+		URL:              pr.URL,
+		HeadBranch:       pr.HeadBranch,
+		HeadRepoFullName: pr.HeadRepoFullName,
+		Author:           pr.Author,
+		Labels:           pr.Labels,
+		CreatedAt:        pr.CreatedAt,
+		UpdatedAt:        pr.UpdatedAt,
+	}
 }
 
 // mergePullRequest waits only immediately before a merge request, so PR discovery
@@ -354,12 +580,28 @@ func (m *Merger) processRepositoryScanOnly(ctx context.Context, repo gh.Reposito
 	if err != nil {
 		repoResult.Skipped = true
 		repoResult.SkipReason = fmt.Sprintf("API error: %v", err)
+		if ctx.Err() != nil {
+			repoResult.SkipReason = string(output.ReasonInterrupted)
+		}
 		return repoResult
 	}
 
+	prs, restored := m.resumePullRequests(context.WithoutCancel(ctx), owner, repo, prs)
+	for _, prResult := range restored {
+		m.events.PullRequestEvaluated(repo.FullName, prResult)
+		repoResult.PullRequests = append(repoResult.PullRequests, prResult)
+	}
+
 	// Process each pull request sequentially (scan only)
 	for _, pr := range prs {
-		prResult := m.evaluatePullRequest(ctx, owner, repo, pr)
+		if ctx.Err() != nil {
+			prResult := interruptedPullRequest(pr)
+			m.events.PullRequestEvaluated(repo.FullName, prResult)
+			repoResult.PullRequests = append(repoResult.PullRequests, prResult)
+			continue
+		}
+		prResult := m.evaluatePullRequest(context.WithoutCancel(ctx), owner, repo, pr)
+		m.events.PullRequestEvaluated(repo.FullName, prResult)
 		repoResult.PullRequests = append(repoResult.PullRequests, prResult)
 	}
 
@@ -368,17 +610,34 @@ func (m *Merger) processRepositoryScanOnly(ctx context.Context, repo gh.Reposito
 
 // evaluatePullRequest evaluates a PR and returns what action would be taken (no side effects).
 func (m *Merger) evaluatePullRequest(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest) output.PullRequestResult {
-	result := output.PullRequestResult{
-		Number:           pr.Number,
-		URL:              pr.URL,
-		HeadBranch:       pr.HeadBranch,
-		Title:            pr.Title,
-		HeadRepoFullName: pr.HeadRepoFullName,
-	}
+	result := newPullRequestResult(pr)
 
 	if m.config.Close {
+		if m.config.Stale && !m.checkStale(ctx, owner, repo, pr, &result) {
+			return result
+		}
+		command, err := m.dependabotIgnoreCommand(pr)
+		if err != nil {
+			result.Action = output.ActionIgnoreFailed
+			result.Reason = err.Error()
+			return result
+		}
 		result.Action = output.ActionWouldClose
-		result.Reason = "would close"
+		steps := "close"
+		if command != "" {
+			result.Action = output.ActionWouldIgnore
+			steps = "post " + command + " and close"
+		}
+		if m.closeComment(repo.FullName, pr.Number) != "" {
+			steps = "comment and " + steps
+		}
+		result.Reason = "would " + steps
+		if s, ok := m.superseded[prKey(repo.FullName, pr.Number)]; ok {
+			result.Reason = fmt.Sprintf("superseded by #%d (%s %s), would comment and close", s.newer, s.pkg, s.version)
+		}
+		if reason, ok := m.staleReasons[prKey(repo.FullName, pr.Number)]; ok {
+			result.Reason = "stale (" + reason + "), " + result.Reason
+		}
 		if m.config.DeleteSourceBranch {
 			result.Reason += " and delete source branch"
 		}
@@ -429,6 +688,13 @@ func (m *Merger) evaluatePullRequest(ctx context.Context, owner string, repo gh.
 
 	// Check for merge conflicts
 	if branchStatus.HasConflict {
+		if m.canRecreate(pr) {
+			strategy := gh.SelectRebaseStrategy(pr)
+			result.Action = output.ActionWouldRecreate
+			result.RebaseStrategy = strategy.Name()
+			result.Reason = fmt.Sprintf("would %s (pull request has merge conflicts)", gh.SelectRecreator(pr).RecreateAction())
+			return result
+		}
 		result.Action = output.ActionSkipConflict
 		result.Reason = "pull request has merge conflicts"
 		result.SkipReason = output.ReasonConflict
@@ -453,13 +719,10 @@ func (m *Merger) evaluatePullRequest(ctx context.Context, owner string, repo gh.
 		}
 
 		// Report what would be done
-		if gh.IsDependabotBranch(pr.HeadBranch) {
-			result.Action = output.ActionWouldRebase
-			result.Reason = fmt.Sprintf("would post @dependabot rebase comment (%d commits behind)", branchStatus.BehindBy)
-		} else {
-			result.Action = output.ActionWouldRebase
-			result.Reason = fmt.Sprintf("would update branch via API (%d commits behind)", branchStatus.BehindBy)
-		}
+		strategy := gh.SelectRebaseStrategy(pr)
+		result.Action = output.ActionWouldRebase
+		result.RebaseStrategy = strategy.Name()
+		result.Reason = fmt.Sprintf("would %s (%d commits behind)", strategy.Action(), branchStatus.BehindBy)
 		return result
 	}
 
@@ -506,10 +769,137 @@ func (m *Merger) printRepoResultWithProgress(repo output.RepositoryResult, curre
 	return lines
 }
 
+// interruptedRepository records a repository that was not scanned because the
+// run was interrupted.
+func interruptedRepository(repo gh.Repository) output.RepositoryResult {
+	return output.RepositoryResult{
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		DefaultBranch: repo.DefaultBranch,
+		Skipped:       true,
+		SkipReason:    string(output.ReasonInterrupted),
+	}
+}
+
+// interruptedPullRequest records a PR that was not evaluated because the run
+// was interrupted.
+func interruptedPullRequest(pr gh.PullRequest) output.PullRequestResult {
+	result := newPullRequestResult(pr)
+	interruptPullRequest(&result, "run interrupted before this pull request was evaluated")
+	return result
+}
+
+// interruptPullRequest marks a PR result as skipped by an interrupt.
+func interruptPullRequest(pr *output.PullRequestResult, reason string) {
+	pr.Action = output.ActionSkipInterrupted
+	pr.Reason = reason
+	pr.SkipReason = output.ReasonInterrupted
+}
+
+// isInterrupted reports whether an interrupt left part of the repository unprocessed.
+func isInterrupted(repo output.RepositoryResult) bool {
+	if repo.Skipped {
+		return repo.SkipReason == string(output.ReasonInterrupted)
+	}
+	for _, pr := range repo.PullRequests {
+		if pr.SkipReason == output.ReasonInterrupted {
+			return true
+		}
+	}
+	return false
+}
+
+// isComplete reports whether a repository was fully processed, so a resumed run
+// can skip it. Repositories left incomplete by an interrupt or an API error are
+// processed again.
+func isComplete(repo output.RepositoryResult) bool {
+	if repo.Skipped || isInterrupted(repo) {
+		return false
+	}
+	for _, pr := range repo.PullRequests {
+		if pr.SkipReason == output.ReasonAPIError {
+			return false
+		}
+	}
+	return true
+}
+
+// resumePullRequests applies what the state recorded for a repository that the
+// resumed run did not complete. PRs that were in flight when it stopped are
+// re-verified: those since merged by a merge run or closed by a close run are
+// reported as such and not processed again, other closed ones are skipped as
+// closed outside ghprmerge, and open ones are evaluated from scratch. Results
+// recorded for PRs that are no longer open are kept. It returns the PRs still
+// to process and the restored results.
+func (m *Merger) resumePullRequests(ctx context.Context, owner string, repo gh.Repository, prs []gh.PullRequest) ([]gh.PullRequest, []output.PullRequestResult) {
+	prior := m.state.PullRequests(repo.FullName)
+	if len(prior) == 0 {
+		return prs, nil
+	}
+
+	open := make(map[int]bool, len(prs))
+	for _, pr := range prs {
+		open[pr.Number] = true
+	}
+
+	var restored []output.PullRequestResult
+	finished := make(map[int]bool)
+	for _, p := range prior {
+		switch {
+		case p.InFlight:
+			current, err := m.client.GetPullRequest(ctx, owner, repo.Name, p.Number)
+			if err != nil {
+				// Open PRs are evaluated again below; others would drop out
+				if !open[p.Number] {
+					restored = append(restored, output.PullRequestResult{
+						Number:     p.Number,
+						Action:     output.ActionSkipAPIError,
+						Reason:     fmt.Sprintf("failed to verify pull request in flight when the previous run stopped: %v", err),
+						SkipReason: output.ReasonAPIError,
+					})
+				}
+				continue
+			}
+			if current == nil || current.State != "closed" {
+				continue
+			}
+			result := newPullRequestResult(*current)
+			switch {
+			case current.Merged && m.config.Merge:
+				result.Action = output.ActionMerged
+				result.Reason = "merged before the previous run stopped (verified on resume)"
+			case !current.Merged && m.config.Close:
+				result.Action = output.ActionClosed
+				result.Reason = "closed before the previous run stopped (verified on resume)"
+			default:
+				state := "closed"
+				if current.Merged {
+					state = "merged"
+				}
+				result.Action = output.ActionSkipClosedOutside
+				result.Reason = state + " outside ghprmerge while the previous run was stopped (verified on resume)"
+				result.SkipReason = output.ReasonClosedOutside
+			}
+			m.state.FinishPullRequest(repo.FullName, result)
+			restored = append(restored, result)
+			finished[p.Number] = true
+		case p.Result != nil && !open[p.Number]:
+			restored = append(restored, *p.Result)
+		}
+	}
+
+	remaining := make([]gh.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if !finished[pr.Number] {
+			remaining = append(remaining, pr)
+		}
+	}
+	return remaining, restored
+}
+
 func hasCompletedActions(repo output.RepositoryResult) bool {
 	for _, pr := range repo.PullRequests {
-		switch pr.Action {
-		case output.ActionMerged, output.ActionMergeFailed, output.ActionRebased, output.ActionRebaseFailed, output.ActionClosed, output.ActionCloseFailed:
+		if output.IsCompletedAction(pr.Action) {
 			return true
 		}
 	}
@@ -517,7 +907,7 @@ func hasCompletedActions(repo output.RepositoryResult) bool {
 }
 
 func hasPendingActions(result *output.RunResult) bool {
-	return result.Summary.WouldMerge > 0 || result.Summary.WouldRebase > 0 || result.Summary.WouldClose > 0
+	return result.Summary.HasPendingActions()
 }
 
 // updateSummary updates the run summary based on a PR result.
@@ -541,6 +931,18 @@ func (m *Merger) updateSummary(summary *output.RunSummary, pr output.PullRequest
 		summary.WouldRebase++
 	case output.ActionWouldClose:
 		summary.WouldClose++
+	case output.ActionRecreateRequested:
+		summary.RecreateRequested++
+	case output.ActionRecreateFailed:
+		summary.RecreateFailed++
+	case output.ActionWouldRecreate:
+		summary.WouldRecreate++
+	case output.ActionIgnored:
+		summary.IgnoredSuccess++
+	case output.ActionIgnoreFailed:
+		summary.IgnoreFailed++
+	case output.ActionWouldIgnore:
+		summary.WouldIgnore++
 	case output.ActionReadyMerge:
 		summary.ReadyToMerge++
 	default:
@@ -571,6 +973,11 @@ func (m *Merger) discoverRepositories(ctx context.Context) ([]gh.Repository, err
 			}
 		}
 
+		// A selection only reaches the repositories it names
+		if m.selection != nil && len(m.selection[repo.Name]) == 0 {
+			continue
+		}
+
 		repos = append(repos, repo)
 	}
 
@@ -593,12 +1000,37 @@ func (m *Merger) processRepository(ctx context.Context, repo gh.Repository) outp
 	if err != nil {
 		repoResult.Skipped = true
 		repoResult.SkipReason = fmt.Sprintf("API error: %v", err)
+		if ctx.Err() != nil {
+			repoResult.SkipReason = string(output.ReasonInterrupted)
+		}
 		return repoResult
 	}
 
-	// Process each pull request sequentially
+	prs, restored := m.resumePullRequests(context.WithoutCancel(ctx), owner, repo, prs)
+	for _, prResult := range restored {
+		m.events.PullRequestEvaluated(repo.FullName, prResult)
+		repoResult.PullRequests = append(repoResult.PullRequests, prResult)
+	}
+
+	// Process each pull request sequentially. Once interrupted, the PR in flight
+	// is finished so its action is not abandoned halfway, and the rest are skipped.
 	for _, pr := range prs {
-		prResult := m.processPullRequest(ctx, owner, repo, pr)
+		if ctx.Err() != nil {
+			prResult := interruptedPullRequest(pr)
+			m.events.PullRequestEvaluated(repo.FullName, prResult)
+			repoResult.PullRequests = append(repoResult.PullRequests, prResult)
+			continue
+		}
+		work := context.WithoutCancel(ctx)
+		m.state.StartPullRequest(repo.FullName, pr.Number)
+		prResult := m.processPullRequest(work, owner, repo, pr)
+		m.syncSkipLabel(work, owner, repo.Name, &prResult)
+		m.syncStatusComment(work, owner, repo.Name, &prResult)
+		m.state.FinishPullRequest(repo.FullName, prResult)
+		m.events.PullRequestEvaluated(repo.FullName, prResult)
+		if output.IsCompletedAction(prResult.Action) {
+			m.events.ActionExecuted(repo.FullName, prResult)
+		}
 		repoResult.PullRequests = append(repoResult.PullRequests, prResult)
 	}
 
@@ -634,6 +1066,14 @@ func (m *Merger) discoverPullRequests(ctx context.Context, repo gh.Repository) (
 			continue
 		}
 
+		// A selection names the exact PRs instead of branch patterns
+		if m.selection != nil {
+			if slices.Contains(m.selection[repo.Name], pr.Number) && m.matchesFilters(pr) {
+				prs = append(prs, pr)
+			}
+			continue
+		}
+
 		// Match against any of the configured source branch patterns
 		matchedPattern := ""
 		for _, pattern := range m.config.SourceBranches {
@@ -655,26 +1095,54 @@ func (m *Merger) discoverPullRequests(ctx context.Context, repo gh.Repository) (
 			continue
 		}
 
-		// Apply author filter if specified
-		if m.config.Author != "" && pr.Author != m.config.Author {
+		if !m.matchesFilters(pr) {
 			continue
 		}
 
 		prs = append(prs, pr)
 	}
 
+	// --superseded only closes PRs that a newer open PR replaces
+	if m.config.Superseded {
+		var open []gh.PullRequest
+		for _, pr := range allPRs {
+			if !pr.Draft && pr.BaseBranch == repo.DefaultBranch {
+				open = append(open, pr)
+			}
+		}
+		prs = m.filterSuperseded(repo.FullName, open, prs)
+	}
+
 	return prs, nil
 }
 
+// matchesFilters applies the --author, label, and age filters.
+func (m *Merger) matchesFilters(pr gh.PullRequest) bool {
+	if m.config.Author != "" && pr.Author != m.config.Author {
+		return false
+	}
+	return m.matchesLabelFilters(pr) && m.matchesAgeFilters(pr)
+}
+
+// matchesAgeFilters reports whether a PR passes the --min-age and --max-age
+// filters. A PR without a creation time only passes when neither is set.
+func (m *Merger) matchesAgeFilters(pr gh.PullRequest) bool {
+	if m.config.MinAge == 0 && m.config.MaxAge == 0 {
+		return true
+	}
+	if pr.CreatedAt.IsZero() {
+		return false
+	}
+	age := time.Since(pr.CreatedAt)
+	if m.config.MinAge > 0 && age < m.config.MinAge {
+		return false
+	}
+	return m.config.MaxAge == 0 || age <= m.config.MaxAge
+}
+
 // processPullRequest processes a single pull request and returns the result.
 func (m *Merger) processPullRequest(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest) output.PullRequestResult {
-	result := output.PullRequestResult{
-		Number:           pr.Number,
-		URL:              pr.URL,
-		HeadBranch:       pr.HeadBranch,
-		Title:            pr.Title,
-		HeadRepoFullName: pr.HeadRepoFullName,
-	}
+	result := newPullRequestResult(pr)
 
 	if m.config.Close {
 		return m.closePullRequest(ctx, owner, repo, pr, result)
@@ -724,6 +1192,11 @@ func (m *Merger) processPullRequest(ctx context.Context, owner string, repo gh.R
 
 	// Check for merge conflicts
 	if branchStatus.HasConflict {
+		if m.canRecreate(pr) {
+			result.RebaseStrategy = gh.SelectRebaseStrategy(pr).Name()
+			m.executeRecreate(ctx, owner, repo.Name, &result)
+			return result
+		}
 		result.Action = output.ActionSkipConflict
 		result.Reason = "pull request has merge conflicts"
 		result.SkipReason = output.ReasonConflict
@@ -740,18 +1213,16 @@ func (m *Merger) processPullRequest(ctx context.Context, owner string, repo gh.R
 }
 
 func (m *Merger) closePullRequest(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest, result output.PullRequestResult) output.PullRequestResult {
+	if m.config.Stale && !m.checkStale(ctx, owner, repo, pr, &result) {
+		return result
+	}
 	m.executeClose(ctx, owner, repo.Name, &result)
 	return result
 }
 
 // handleOutdatedBranch handles PRs where the branch is behind the default branch.
 func (m *Merger) handleOutdatedBranch(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest, branchStatus *gh.BranchStatus, checksState string) output.PullRequestResult {
-	result := output.PullRequestResult{
-		Number:     pr.Number,
-		URL:        pr.URL,
-		HeadBranch: pr.HeadBranch,
-		Title:      pr.Title,
-	}
+	result := newPullRequestResult(pr)
 
 	// If skip-rebase is enabled with merge, proceed to merge despite being behind
 	if m.config.SkipRebase && m.config.Merge {
@@ -774,36 +1245,29 @@ func (m *Merger) handleOutdatedBranch(ctx context.Context, owner string, repo gh
 		return result
 	}
 
-	// Perform actual rebase/update
-	if gh.IsDependabotBranch(pr.HeadBranch) {
-		if err := m.client.PostRebaseComment(ctx, owner, repo.Name, pr.Number); err != nil {
-			result.Action = output.ActionRebaseFailed
-			result.Reason = fmt.Sprintf("failed to post rebase comment: %v", err)
-			return result
-		}
+	// Perform actual rebase/update, letting bots update the branches they own
+	strategy := gh.SelectRebaseStrategy(pr)
+	result.RebaseStrategy = strategy.Name()
+	err := strategy.RequestRebase(ctx, m.client, owner, repo.Name, pr)
+	if errors.Is(err, gh.ErrRebaseAlreadyRequested) {
 		result.Action = output.ActionRebased
-		result.Reason = fmt.Sprintf("posted @dependabot rebase comment (%d commits behind)", branchStatus.BehindBy)
-	} else {
-		if err := m.client.UpdateBranch(ctx, owner, repo.Name, pr.Number); err != nil {
-			result.Action = output.ActionRebaseFailed
-			result.Reason = fmt.Sprintf("failed to update branch: %v", err)
-			return result
-		}
-		result.Action = output.ActionRebased
-		result.Reason = fmt.Sprintf("branch update requested via API (%d commits behind)", branchStatus.BehindBy)
+		result.Reason = fmt.Sprintf("%v (%d commits behind)", err, branchStatus.BehindBy)
+		return result
+	}
+	if err != nil {
+		result.Action = output.ActionRebaseFailed
+		result.Reason = fmt.Sprintf("failed to %s: %v", strategy.Action(), err)
+		return result
 	}
+	result.Action = output.ActionRebased
+	result.Reason = fmt.Sprintf("%s (%d commits behind)", strategy.Completed(), branchStatus.BehindBy)
 
 	return result
 }
 
 // handleMergeReady handles PRs that are ready to merge.
 func (m *Merger) handleMergeReady(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest, checksState string) output.PullRequestResult {
-	result := output.PullRequestResult{
-		Number:     pr.Number,
-		URL:        pr.URL,
-		HeadBranch: pr.HeadBranch,
-		Title:      pr.Title,
-	}
+	result := newPullRequestResult(pr)
 
 	// If merge is not enabled, just report
 	if !m.config.Merge {
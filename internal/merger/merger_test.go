@@ -3,8 +3,10 @@ package merger
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -13,6 +15,7 @@ import (
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/config"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/github"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/state"
 )
 
 func TestMergeDelayHonorsContextCancellation(t *testing.T) {
@@ -754,6 +757,9 @@ func TestMergerCloseDeletesMatchingSourceBranch(t *testing.T) {
 	if got := result.Repositories[0].PullRequests[0]; got.Action != output.ActionClosed || got.Reason != "successfully closed and source branch deleted" {
 		t.Errorf("close result = %+v, want successful close with deleted branch", got)
 	}
+	if !result.Repositories[0].PullRequests[0].Dependabot {
+		t.Error("Dependabot PR result is not flagged as Dependabot")
+	}
 }
 
 func TestMergerCloseReportsSourceBranchDeletionFailure(t *testing.T) {
@@ -820,6 +826,206 @@ func TestMergerCloseDoesNotDeleteBranchWithoutHeadRepository(t *testing.T) {
 	}
 }
 
+func TestMergerCloseWithDependabotIgnore(t *testing.T) {
+	for _, confirm := range []bool{false, true} {
+		t.Run(fmt.Sprintf("confirm=%v", confirm), func(t *testing.T) {
+			mock := github.NewMockClient()
+			mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+			mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+				{Number: 1, HeadBranch: "dependabot/npm/foo", BaseBranch: "main", Author: "dependabot[bot]"},
+				{Number: 2, HeadBranch: "dependabot-like/npm/bar", BaseBranch: "main", Author: "octocat"},
+			}
+
+			m := New(mock, &config.Config{
+				Org:              "testorg",
+				SourceBranches:   []string{"dependabot"},
+				SourceBranch:     "dependabot",
+				Close:            true,
+				Confirm:          confirm,
+				DependabotIgnore: "major-version",
+			}, nil)
+			result, err := m.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if confirm {
+				if got := result.Repositories[0].PullRequests[0].Action; got != output.ActionWouldIgnore {
+					t.Fatalf("scan action = %q, want %q", got, output.ActionWouldIgnore)
+				}
+				if result.Summary.WouldIgnore != 1 || result.Summary.WouldClose != 1 {
+					t.Errorf("scan summary = %+v, want one would ignore and one would close", result.Summary)
+				}
+				result, err = m.RunWithActions(context.Background(), result)
+				if err != nil {
+					t.Fatalf("RunWithActions() error = %v", err)
+				}
+			}
+
+			if got, want := mock.PostedComments["testorg/repo1/\x01"], []string{"@dependabot ignore this major version"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("comments on #1 = %v, want %v", got, want)
+			}
+			if got := mock.PostedComments["testorg/repo1/\x02"]; len(got) != 0 {
+				t.Errorf("comments on #2 = %v, want none for non-Dependabot PR", got)
+			}
+			if len(mock.CloseCalls) != 2 {
+				t.Errorf("ClosePullRequest called %d times, want 2", len(mock.CloseCalls))
+			}
+			prs := result.Repositories[0].PullRequests
+			if prs[0].Action != output.ActionIgnored || prs[1].Action != output.ActionClosed {
+				t.Errorf("actions = (%q, %q), want (%q, %q)", prs[0].Action, prs[1].Action, output.ActionIgnored, output.ActionClosed)
+			}
+			if result.Summary.IgnoredSuccess != 1 || result.Summary.ClosedSuccess != 1 {
+				t.Errorf("summary = %+v, want one ignored and one closed", result.Summary)
+			}
+		})
+	}
+}
+
+func TestMergerCloseWithDependabotIgnoreDoesNotCloseWhenCommentFails(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "dependabot/npm/foo", BaseBranch: "main"}}
+	mock.PostCommentErr["testorg/repo1/\x01"] = errors.New("forbidden")
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"dependabot/"}, SourceBranch: "dependabot/", Close: true, DependabotIgnore: "dependency"}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.CloseCalls) != 0 {
+		t.Errorf("ClosePullRequest called %d times, want 0 after comment failure", len(mock.CloseCalls))
+	}
+	got := result.Repositories[0].PullRequests[0]
+	if got.Action != output.ActionIgnoreFailed || result.Summary.IgnoreFailed != 1 {
+		t.Errorf("close result = %+v, want ignore failure", got)
+	}
+}
+
+func TestMergerCloseWithUnknownDependabotIgnoreScope(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "dependabot/npm/foo", BaseBranch: "main"}}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"dependabot/"}, SourceBranch: "dependabot/", Close: true, DependabotIgnore: "patch-version"}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.CloseCalls) != 0 || len(mock.PostCommentCalls) != 0 {
+		t.Errorf("CloseCalls = %v, PostCommentCalls = %v, want none for an unknown scope", mock.CloseCalls, mock.PostCommentCalls)
+	}
+	got := result.Repositories[0].PullRequests[0]
+	if got.Action != output.ActionIgnoreFailed || !strings.Contains(got.Reason, "patch-version") {
+		t.Errorf("close result = %+v, want ignore failure naming the scope", got)
+	}
+}
+
+func TestMergerRebaseRecreatesConflictedDependabotPRs(t *testing.T) {
+	for _, confirm := range []bool{false, true} {
+		t.Run(fmt.Sprintf("confirm=%v", confirm), func(t *testing.T) {
+			mock := github.NewMockClient()
+			mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+			mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+				{Number: 1, HeadBranch: "dependabot/npm/foo", BaseBranch: "main", HeadSHA: "sha1"},
+				{Number: 2, HeadBranch: "dependabot/npm/bar", BaseBranch: "main", HeadSHA: "sha2", Author: "octocat"},
+			}
+			mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+			mock.BranchStatuses["testorg/repo1/\x02"] = &github.BranchStatus{UpToDate: false, BehindBy: 1}
+
+			m := New(mock, &config.Config{
+				Org:                "testorg",
+				SourceBranches:     []string{"dependabot/"},
+				SourceBranch:       "dependabot/",
+				Rebase:             true,
+				Confirm:            confirm,
+				RecreateConflicted: true,
+			}, nil)
+			result, err := m.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if confirm {
+				if result.Summary.WouldRecreate != 1 || result.Summary.WouldRebase != 1 {
+					t.Fatalf("scan summary = %+v, want one would recreate and one would rebase", result.Summary)
+				}
+				result, err = m.RunWithActions(context.Background(), result)
+				if err != nil {
+					t.Fatalf("RunWithActions() error = %v", err)
+				}
+			}
+
+			if got, want := mock.PostedComments["testorg/repo1/\x01"], []string{"@dependabot recreate"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("comments on #1 = %v, want %v", got, want)
+			}
+			if len(mock.PostRebaseCalls) != 1 {
+				t.Errorf("PostRebaseComment called %d times, want 1", len(mock.PostRebaseCalls))
+			}
+			if got := result.Repositories[0].PullRequests[0].Action; got != output.ActionRecreateRequested {
+				t.Errorf("Action = %q, want %q", got, output.ActionRecreateRequested)
+			}
+			if result.Summary.RecreateRequested != 1 || result.Summary.RebasedSuccess != 1 {
+				t.Errorf("summary = %+v, want one recreate requested and one rebased", result.Summary)
+			}
+		})
+	}
+}
+
+func TestMergerMergeRecreatesConflictedBotPRs(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/lodash", BaseBranch: "main", HeadSHA: "sha1", Author: "renovate[bot]", Body: "- [ ] <!-- rebase-check -->rebase/retry"},
+		{Number: 2, HeadBranch: "deps/react", BaseBranch: "main", HeadSHA: "sha2", Author: "octocat"},
+	}
+	mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+	mock.BranchStatuses["testorg/repo1/\x02"] = &github.BranchStatus{HasConflict: true}
+
+	m := New(mock, &config.Config{
+		Org:                "testorg",
+		SourceBranches:     []string{"deps/"},
+		SourceBranch:       "deps/",
+		Merge:              true,
+		RecreateConflicted: true,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	prs := result.Repositories[0].PullRequests
+	if prs[0].Action != output.ActionRecreateRequested || prs[0].RebaseStrategy != github.RebaseStrategyRenovate {
+		t.Errorf("renovate PR = (%q, %q), want recreate requested via renovate", prs[0].Action, prs[0].RebaseStrategy)
+	}
+	if !strings.Contains(mock.UpdatedBodies["testorg/repo1/\x01"], "- [x] <!-- rebase-check -->") {
+		t.Errorf("updated body = %q, want ticked rebase/retry checkbox", mock.UpdatedBodies["testorg/repo1/\x01"])
+	}
+	if prs[1].Action != output.ActionSkipConflict {
+		t.Errorf("non-bot PR action = %q, want %q", prs[1].Action, output.ActionSkipConflict)
+	}
+	if len(mock.MergeCalls) != 0 {
+		t.Errorf("MergePullRequest called %d times, want 0", len(mock.MergeCalls))
+	}
+}
+
+func TestMergerRebaseSkipsConflictsWithoutRecreate(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "dependabot/npm/foo", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"dependabot/"}, SourceBranch: "dependabot/", Rebase: true}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.PostCommentCalls) != 0 {
+		t.Errorf("PostComment called %d times, want 0", len(mock.PostCommentCalls))
+	}
+	if got := result.Repositories[0].PullRequests[0].Action; got != output.ActionSkipConflict {
+		t.Errorf("Action = %q, want %q", got, output.ActionSkipConflict)
+	}
+}
+
 func TestMergerRebaseOnly(t *testing.T) {
 	mock := github.NewMockClient()
 	mock.Repositories = []github.Repository{
@@ -886,6 +1092,100 @@ func TestMergerRebaseOnly(t *testing.T) {
 	}
 }
 
+func TestMergerRebaseRenovateTicksCheckbox(t *testing.T) {
+	for _, confirm := range []bool{false, true} {
+		t.Run(fmt.Sprintf("confirm=%v", confirm), func(t *testing.T) {
+			mock := github.NewMockClient()
+			mock.Repositories = []github.Repository{
+				{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+			}
+			mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+				{
+					Number:     1,
+					Title:      "Update dependency lodash to v4.17.21",
+					HeadBranch: "renovate/lodash-4.x",
+					BaseBranch: "main",
+					HeadSHA:    "abc123",
+					Author:     "renovate[bot]",
+					Body:       "- [ ] <!-- rebase-check -->If you want to rebase/retry this PR, check this box",
+				},
+			}
+			key := fmt.Sprintf("testorg/repo1/%c", rune(1))
+			mock.BranchStatuses[key] = &github.BranchStatus{UpToDate: false, BehindBy: 2}
+
+			cfg := &config.Config{
+				Org:            "testorg",
+				SourceBranch:   "renovate/",
+				SourceBranches: []string{"renovate/"},
+				Rebase:         true,
+				Confirm:        confirm,
+				Command:        config.CommandRebase,
+			}
+
+			m := New(mock, cfg, nil)
+			result, err := m.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			pr := result.Repositories[0].PullRequests[0]
+			if pr.RebaseStrategy != github.RebaseStrategyRenovate {
+				t.Errorf("RebaseStrategy = %q, want %q", pr.RebaseStrategy, github.RebaseStrategyRenovate)
+			}
+			if confirm {
+				if pr.Action != output.ActionWouldRebase || !strings.Contains(pr.Reason, "would tick Renovate rebase checkbox") {
+					t.Fatalf("scan result = (%q, %q), want would rebase via Renovate checkbox", pr.Action, pr.Reason)
+				}
+				result, err = m.RunWithActions(context.Background(), result)
+				if err != nil {
+					t.Fatalf("RunWithActions() error = %v", err)
+				}
+				pr = result.Repositories[0].PullRequests[0]
+			}
+
+			if pr.Action != output.ActionRebased {
+				t.Errorf("Action = %v, want %v (reason %q)", pr.Action, output.ActionRebased, pr.Reason)
+			}
+			if len(mock.UpdateBodyCalls) != 1 {
+				t.Errorf("UpdatePullRequestBody called %d times, want 1", len(mock.UpdateBodyCalls))
+			}
+			if len(mock.UpdateBranchCalls) != 0 || len(mock.PostRebaseCalls) != 0 {
+				t.Errorf("UpdateBranch/PostRebaseComment called (%d, %d), want (0, 0)", len(mock.UpdateBranchCalls), len(mock.PostRebaseCalls))
+			}
+			if !strings.Contains(mock.UpdatedBodies[key], "- [x] <!-- rebase-check -->") {
+				t.Errorf("updated body = %q, want ticked rebase checkbox", mock.UpdatedBodies[key])
+			}
+		})
+	}
+}
+
+func TestMergerRebaseRenovateAlreadyRequested(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "renovate/lodash-4.x", BaseBranch: "main", HeadSHA: "abc123", Body: "- [x] <!-- rebase-check -->rebase"},
+	}
+	mock.BranchStatuses["testorg/repo1/"+string(rune(1))] = &github.BranchStatus{UpToDate: false, BehindBy: 2}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranch:   "renovate/",
+		SourceBranches: []string{"renovate/"},
+		Rebase:         true,
+		Command:        config.CommandRebase,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	pr := result.Repositories[0].PullRequests[0]
+	if pr.Action != output.ActionRebased || pr.Reason != "rebase already requested (2 commits behind)" {
+		t.Errorf("result = (%q, %q), want rebased with rebase already requested", pr.Action, pr.Reason)
+	}
+	if len(mock.UpdateBodyCalls) != 0 {
+		t.Errorf("UpdatePullRequestBody called %d times, want 0", len(mock.UpdateBodyCalls))
+	}
+}
+
 func TestMergerConfirmDefaultDoesNotPrintRepoResultsDuringScan(t *testing.T) {
 	mock := github.NewMockClient()
 	mock.Repositories = []github.Repository{
@@ -1805,3 +2105,884 @@ func TestMergerMultipleSourceBranchesConcurrentSkip(t *testing.T) {
 		t.Errorf("MergedSuccess = %d, want 1", result.Summary.MergedSuccess)
 	}
 }
+
+func TestMergerLabelFilters(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1", Labels: []string{"dependencies", "automerge"}},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2", Labels: []string{"dependencies"}},
+		{Number: 3, HeadBranch: "deps/c", BaseBranch: "main", HeadSHA: "sha3", Labels: []string{"dependencies", "automerge", "do-not-merge"}},
+	}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"deps/"},
+		SourceBranch:   "deps/",
+		Labels:         []string{"dependencies", "automerge"},
+		ExcludeLabels:  []string{"do-not-merge"},
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	prs := result.Repositories[0].PullRequests
+	if len(prs) != 1 || prs[0].Number != 1 {
+		t.Fatalf("pull requests = %+v, want only #1", prs)
+	}
+	if !reflect.DeepEqual(prs[0].Labels, []string{"dependencies", "automerge"}) {
+		t.Errorf("Labels = %v, want [dependencies automerge]", prs[0].Labels)
+	}
+}
+
+func TestMergerLabelSkipped(t *testing.T) {
+	for _, confirm := range []bool{false, true} {
+		t.Run(fmt.Sprintf("confirm=%v", confirm), func(t *testing.T) {
+			mock := github.NewMockClient()
+			mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+			mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+				{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1", Labels: []string{"ghprmerge:checks-pending"}},
+				{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2", Labels: []string{"ghprmerge:conflict"}},
+				{Number: 3, HeadBranch: "deps/c", BaseBranch: "main", HeadSHA: "sha3", Labels: []string{"ghprmerge:conflict", "dependencies"}},
+			}
+			mock.CheckStatuses["testorg/repo1/sha1"] = &github.CheckStatus{AllPassing: false, Details: "1 failing"}
+			mock.BranchStatuses["testorg/repo1/\x02"] = &github.BranchStatus{HasConflict: true}
+
+			m := New(mock, &config.Config{
+				Org:            "testorg",
+				SourceBranches: []string{"deps/"},
+				SourceBranch:   "deps/",
+				Merge:          true,
+				Confirm:        confirm,
+				LabelSkipped:   true,
+			}, nil)
+			result, err := m.Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if confirm {
+				if len(mock.AddedLabels)+len(mock.RemovedLabels) != 0 {
+					t.Fatalf("labels changed during confirm scan: added %v, removed %v", mock.AddedLabels, mock.RemovedLabels)
+				}
+				result, err = m.RunWithActions(context.Background(), result)
+				if err != nil {
+					t.Fatalf("RunWithActions() error = %v", err)
+				}
+			}
+
+			wantAdded := map[string][]string{"testorg/repo1/\x01": {"ghprmerge:checks-failing"}}
+			if !reflect.DeepEqual(mock.AddedLabels, wantAdded) {
+				t.Errorf("AddedLabels = %v, want %v", mock.AddedLabels, wantAdded)
+			}
+			wantRemoved := map[string][]string{
+				"testorg/repo1/\x01": {"ghprmerge:checks-pending"},
+				"testorg/repo1/\x03": {"ghprmerge:conflict"},
+			}
+			if !reflect.DeepEqual(mock.RemovedLabels, wantRemoved) {
+				t.Errorf("RemovedLabels = %v, want %v", mock.RemovedLabels, wantRemoved)
+			}
+			if got := result.Repositories[0].PullRequests[2].Labels; !reflect.DeepEqual(got, []string{"dependencies"}) {
+				t.Errorf("merged PR labels = %v, want [dependencies]", got)
+			}
+		})
+	}
+}
+
+func TestMergerLabelSkippedReportsLabelFailure(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+	mock.AddLabelsErr["testorg/repo1/\x01"] = errors.New("label not permitted")
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, LabelSkipped: true}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	pr := result.Repositories[0].PullRequests[0]
+	if pr.Action != output.ActionSkipConflict {
+		t.Errorf("Action = %q, want %q", pr.Action, output.ActionSkipConflict)
+	}
+	if !strings.Contains(pr.Reason, "label update failed: label not permitted") {
+		t.Errorf("Reason = %q, want label failure note", pr.Reason)
+	}
+}
+
+func TestMergerCommentOnSkip(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.CheckStatuses["testorg/repo1/sha1"] = &github.CheckStatus{AllPassing: false, Details: "build failing"}
+	cfg := &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, CommentOnSkip: true}
+
+	// The first run posts the status comment; an unchanged second run leaves it alone.
+	for range 2 {
+		if _, err := New(mock, cfg, nil).Run(context.Background()); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+	comments := mock.Comments["testorg/repo1/\x01"]
+	if len(comments) != 1 || !strings.Contains(comments[0].Body, StatusCommentMarker) || !strings.Contains(comments[0].Body, "build failing") {
+		t.Fatalf("comments = %+v, want one status comment with the reason", comments)
+	}
+	if len(mock.UpdatedComments) != 0 {
+		t.Errorf("UpdatedComments = %v, want none for an unchanged reason", mock.UpdatedComments)
+	}
+
+	// A new reason updates the existing comment in place.
+	mock.CheckStatuses["testorg/repo1/sha1"] = &github.CheckStatus{AllPassing: false, Details: "lint failing"}
+	if _, err := New(mock, cfg, nil).Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	comments = mock.Comments["testorg/repo1/\x01"]
+	if len(comments) != 1 || !strings.Contains(mock.UpdatedComments[comments[0].ID], "lint failing") {
+		t.Fatalf("comments = %+v, updated = %v, want the status comment updated in place", comments, mock.UpdatedComments)
+	}
+
+	// Once the PR merges, the status comment is removed.
+	mock.CheckStatuses["testorg/repo1/sha1"] = &github.CheckStatus{AllPassing: true}
+	result, err := New(mock, cfg, nil).Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := result.Repositories[0].PullRequests[0].Action; got != output.ActionMerged {
+		t.Fatalf("Action = %q, want %q", got, output.ActionMerged)
+	}
+	if len(mock.Comments["testorg/repo1/\x01"]) != 0 || len(mock.DeletedComments) != 1 {
+		t.Errorf("comments = %+v, deleted = %v, want status comment deleted", mock.Comments["testorg/repo1/\x01"], mock.DeletedComments)
+	}
+}
+
+func TestMergerCommentOnSkipIgnoresOtherComments(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.Comments["testorg/repo1/\x01"] = []github.Comment{{ID: 500, Body: "LGTM"}}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, CommentOnSkip: true}, nil)
+	if _, err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.DeletedComments) != 0 || len(mock.PostCommentCalls) != 0 {
+		t.Errorf("deleted = %v, posted = %v, want other comments left alone", mock.DeletedComments, mock.PostCommentCalls)
+	}
+}
+
+func TestMergerCommentOnSkipIgnoresMarkerFromOthers(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Login = "ghprmerge-bot"
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+	mock.Comments["testorg/repo1/\x01"] = []github.Comment{
+		{ID: 500, Author: "octocat", Body: StatusCommentMarker + "\nquoting the bot"},
+		{ID: 501, Author: "ghprmerge-bot", Body: "Why was this skipped? " + StatusCommentMarker},
+	}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, CommentOnSkip: true}, nil)
+	if _, err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.UpdatedComments) != 0 || len(mock.DeletedComments) != 0 {
+		t.Errorf("updated = %v, deleted = %v, want comments by others left alone", mock.UpdatedComments, mock.DeletedComments)
+	}
+	if len(mock.PostCommentCalls) != 1 {
+		t.Errorf("PostComment called %d times, want a new status comment", len(mock.PostCommentCalls))
+	}
+}
+
+func TestMergerCommentOnSkipLoginFailure(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.LoginErr = errors.New("resource not accessible by integration")
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"}}
+	mock.BranchStatuses["testorg/repo1/\x01"] = &github.BranchStatus{HasConflict: true}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, CommentOnSkip: true}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.PostCommentCalls) != 0 {
+		t.Errorf("PostComment called %d times, want none without the token owner", len(mock.PostCommentCalls))
+	}
+	if got := result.Repositories[0].PullRequests[0].Reason; !strings.Contains(got, "status comment failed: failed to look up the token owner") {
+		t.Errorf("reason = %q, want status comment failure", got)
+	}
+}
+
+func TestMergerRunWithActionsSkipsDeselected(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, Confirm: true}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	output.Deselect(result, []output.PendingRef{{Repo: 0, PullRequest: 1}})
+
+	result, err = m.RunWithActions(context.Background(), result)
+	if err != nil {
+		t.Fatalf("RunWithActions() error = %v", err)
+	}
+	if !reflect.DeepEqual(mock.MergeCalls, []string{"testorg/repo1/\x01"}) {
+		t.Errorf("MergeCalls = %q, want only #1", mock.MergeCalls)
+	}
+	if got := result.Repositories[0].PullRequests[1].Action; got != output.ActionSkipDeselected {
+		t.Errorf("Action = %q, want %q", got, output.ActionSkipDeselected)
+	}
+	if result.Summary.MergedSuccess != 1 || result.Summary.SkippedByReason[string(output.ReasonDeselected)] != 1 {
+		t.Errorf("summary = %+v, want one merged and one deselected", result.Summary)
+	}
+}
+
+func TestMergerTUIScansWithoutActing(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1", State: "open"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2", State: "open"},
+	}
+	mock.CheckStatuses["testorg/repo1/sha2"] = &github.CheckStatus{Pending: true, Details: "1 pending"}
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, TUI: true}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.MergeCalls) != 0 || result.Summary.WouldMerge != 1 {
+		t.Fatalf("scan merged %d PRs with summary %+v, want scan only", len(mock.MergeCalls), result.Summary)
+	}
+
+	repo := result.Repositories[0]
+	m.ExecutePullRequest(context.Background(), repo, &repo.PullRequests[0])
+	if repo.PullRequests[0].Action != output.ActionMerged || len(mock.MergeCalls) != 1 {
+		t.Errorf("ExecutePullRequest: action = %q, merge calls = %d, want one merge", repo.PullRequests[0].Action, len(mock.MergeCalls))
+	}
+
+	mock.CheckStatuses["testorg/repo1/sha2"] = &github.CheckStatus{AllPassing: true}
+	refreshed, err := m.RefreshPullRequest(context.Background(), repo, 2)
+	if err != nil {
+		t.Fatalf("RefreshPullRequest() error = %v", err)
+	}
+	if refreshed.Action != output.ActionWouldMerge {
+		t.Errorf("refreshed action = %q, want %q", refreshed.Action, output.ActionWouldMerge)
+	}
+	repo.PullRequests[1] = refreshed
+
+	m.Summarize(result)
+	if result.Summary.MergedSuccess != 1 || result.Summary.WouldMerge != 1 || result.Summary.Skipped != 0 {
+		t.Errorf("summary = %+v, want one merged and one would merge", result.Summary)
+	}
+}
+
+// decodeEvents parses NDJSON output into events.
+func decodeEvents(t *testing.T, data []byte) []output.Event {
+	t.Helper()
+	var events []output.Event
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var event output.Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid event line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// eventTypes lists the types of the given events.
+func eventTypes(events []output.Event) []output.EventType {
+	var types []output.EventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestMergerStreamsEvents(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+	mock.CheckStatuses["testorg/repo1/sha2"] = &github.CheckStatus{Pending: true, Details: "1 pending"}
+
+	var buf bytes.Buffer
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, RepoLimit: 1}, nil)
+	m.SetEventStream(output.NewEventStream(&buf))
+	if _, err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	events := decodeEvents(t, buf.Bytes())
+	want := []output.EventType{
+		output.EventRunStarted,
+		output.EventPullRequest, output.EventAction, // #1 merged
+		output.EventPullRequest, // #2 skipped, no action
+		output.EventRepository,  // repo1
+		output.EventRepository,  // repo2 over the limit
+	}
+	if got := eventTypes(events); !reflect.DeepEqual(got, want) {
+		t.Fatalf("event types = %v, want %v", got, want)
+	}
+
+	if events[0].Metadata == nil || events[0].Metadata.Org != "testorg" {
+		t.Errorf("run_started metadata = %+v", events[0].Metadata)
+	}
+	if pr := events[2].PullRequest; events[2].Repository != "testorg/repo1" || pr == nil || pr.Number != 1 || pr.Action != output.ActionMerged {
+		t.Errorf("action event = %+v", events[2])
+	}
+	if pr := events[3].PullRequest; pr == nil || pr.Action != output.ActionSkipChecksPending {
+		t.Errorf("pull_request event for #2 = %+v", events[3])
+	}
+	if e := events[4]; e.Repository != "testorg/repo1" || e.Candidates == nil || *e.Candidates != 2 {
+		t.Errorf("repository event = %+v", e)
+	}
+	if e := events[5]; e.Repository != "testorg/repo2" || !e.Skipped || e.SkipReason != "repo limit reached" {
+		t.Errorf("skipped repository event = %+v", e)
+	}
+}
+
+func TestMergerStreamsEventsWithConfirm(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+	}
+
+	var buf bytes.Buffer
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, Confirm: true}, nil)
+	m.SetEventStream(output.NewEventStream(&buf))
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	events := decodeEvents(t, buf.Bytes())
+	if got, want := eventTypes(events), []output.EventType{output.EventRunStarted, output.EventPullRequest, output.EventRepository}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("scan event types = %v, want %v", got, want)
+	}
+	if pr := events[1].PullRequest; pr == nil || pr.Action != output.ActionWouldMerge {
+		t.Errorf("scan pull_request event = %+v, want would merge", events[1])
+	}
+
+	buf.Reset()
+	if _, err := m.RunWithActions(context.Background(), result); err != nil {
+		t.Fatalf("RunWithActions() error = %v", err)
+	}
+	events = decodeEvents(t, buf.Bytes())
+	if len(events) != 1 || events[0].Type != output.EventAction || events[0].PullRequest.Action != output.ActionMerged {
+		t.Errorf("execution events = %+v, want one merged action", events)
+	}
+}
+
+// cancelOnMergeClient cancels the run context when a merge is requested, as
+// an interrupt arriving while the merge call is in flight would.
+type cancelOnMergeClient struct {
+	*github.MockClient
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnMergeClient) MergePullRequest(ctx context.Context, owner, repo string, prNumber int) error {
+	c.cancel()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.MockClient.MergePullRequest(ctx, owner, repo, prNumber)
+}
+
+func TestMergerRunInterrupted(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+	mock.PullRequests["testorg/repo2"] = []github.PullRequest{
+		{Number: 3, HeadBranch: "deps/c", BaseBranch: "main", HeadSHA: "sha3"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cancelOnMergeClient{MockClient: mock, cancel: cancel}
+	m := New(client, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true}, nil)
+	result, err := m.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !result.Metadata.Interrupted {
+		t.Error("Metadata.Interrupted = false, want true")
+	}
+	if len(mock.MergeCalls) != 1 {
+		t.Fatalf("MergePullRequest called %d times, want 1 (in-flight merge finished)", len(mock.MergeCalls))
+	}
+	prs := result.Repositories[0].PullRequests
+	if prs[0].Action != output.ActionMerged {
+		t.Errorf("PR #1 action = %q, want %q", prs[0].Action, output.ActionMerged)
+	}
+	if prs[1].Action != output.ActionSkipInterrupted || prs[1].SkipReason != output.ReasonInterrupted {
+		t.Errorf("PR #2 = %q (%q), want interrupted", prs[1].Action, prs[1].SkipReason)
+	}
+	repo2 := result.Repositories[1]
+	if !repo2.Skipped || repo2.SkipReason != string(output.ReasonInterrupted) {
+		t.Errorf("repo2 skipped = %v (%q), want interrupted", repo2.Skipped, repo2.SkipReason)
+	}
+	if result.Summary.ReposSkipped != 1 || result.Summary.MergedSuccess != 1 || result.Summary.SkippedByReason["interrupted"] != 1 {
+		t.Errorf("summary = %+v, want 1 repo skipped, 1 merged, 1 interrupted", result.Summary)
+	}
+}
+
+func TestMergerRunWithActionsInterrupted(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cancelOnMergeClient{MockClient: mock, cancel: cancel}
+	m := New(client, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true, Confirm: true}, nil)
+	scan, err := m.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if scan.Metadata.Interrupted {
+		t.Fatal("scan Metadata.Interrupted = true, want false")
+	}
+
+	result, err := m.RunWithActions(ctx, scan)
+	if err != nil {
+		t.Fatalf("RunWithActions() error = %v", err)
+	}
+	prs := result.Repositories[0].PullRequests
+	if prs[0].Action != output.ActionMerged {
+		t.Errorf("PR #1 action = %q, want %q", prs[0].Action, output.ActionMerged)
+	}
+	if prs[1].Action != output.ActionSkipInterrupted || !strings.Contains(prs[1].Reason, "would merge") {
+		t.Errorf("PR #2 = %q (%q), want interrupted before would merge", prs[1].Action, prs[1].Reason)
+	}
+	if !result.Metadata.Interrupted || result.Summary.WouldMerge != 0 || result.Summary.SkippedByReason["interrupted"] != 1 {
+		t.Errorf("result = %+v %+v, want interrupted with no pending merges", result.Metadata, result.Summary)
+	}
+}
+
+func TestMergerResumesInterruptedRun(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+	mock.PullRequests["testorg/repo2"] = []github.PullRequest{
+		{Number: 3, HeadBranch: "deps/c", BaseBranch: "main", HeadSHA: "sha3"},
+	}
+	cfg := &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true}
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := New(&cancelOnMergeClient{MockClient: mock, cancel: cancel}, cfg, nil)
+	m.SetState(state.New(path, "merge", "testorg", cfg.SourceBranches))
+	if _, err := m.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// PR #1 was merged, so GitHub no longer lists it as open.
+	mock.PullRequests["testorg/repo1"] = mock.PullRequests["testorg/repo1"][1:]
+
+	st, err := state.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	m = New(mock, cfg, nil)
+	m.SetState(st)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("resumed Run() error = %v", err)
+	}
+
+	if !result.Metadata.Resumed || result.Metadata.Interrupted {
+		t.Errorf("metadata = %+v, want resumed and not interrupted", result.Metadata)
+	}
+	if len(mock.MergeCalls) != 3 {
+		t.Errorf("MergePullRequest called %d times, want 3", len(mock.MergeCalls))
+	}
+	prs := result.Repositories[0].PullRequests
+	if len(prs) != 2 || prs[0].Number != 1 || prs[0].Action != output.ActionMerged || prs[1].Action != output.ActionMerged {
+		t.Errorf("repo1 PRs = %+v, want #1 restored and #2 merged", prs)
+	}
+	if result.Summary.MergedSuccess != 3 || result.Summary.ReposProcessed != 2 {
+		t.Errorf("summary = %+v, want 3 merged across 2 repos", result.Summary)
+	}
+	if _, ok := st.Completed("testorg/repo2"); !ok {
+		t.Error("repo2 not recorded as completed")
+	}
+}
+
+func TestMergerResumeSkipsCompletedAndVerifiesInFlight(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.ListPRsErr["testorg/repo1"] = errors.New("completed repositories must not be scanned again")
+	mock.PullRequests["testorg/repo2"] = []github.PullRequest{
+		{Number: 5, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha5", State: "closed", Merged: true},
+		{Number: 6, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha6"},
+	}
+
+	st := state.New(filepath.Join(t.TempDir(), "state.json"), "merge", "testorg", []string{"deps/"})
+	st.CompleteRepository(output.RepositoryResult{Name: "repo1", FullName: "testorg/repo1", PullRequests: []output.PullRequestResult{
+		{Number: 1, Action: output.ActionMerged},
+	}})
+	st.StartPullRequest("testorg/repo2", 5)
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Merge: true}, nil)
+	m.SetState(st)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if result.Repositories[0].Skipped || len(result.Repositories[0].PullRequests) != 1 {
+		t.Errorf("repo1 = %+v, want the recorded result", result.Repositories[0])
+	}
+	if len(mock.MergeCalls) != 1 || mock.MergeCalls[0] != "testorg/repo2/"+string(rune(6)) {
+		t.Errorf("MergeCalls = %+v, want only #6", mock.MergeCalls)
+	}
+	prs := result.Repositories[1].PullRequests
+	if len(prs) != 2 || prs[0].Number != 5 || prs[0].Action != output.ActionMerged || !strings.Contains(prs[0].Reason, "verified on resume") {
+		t.Errorf("repo2 PRs = %+v, want #5 verified as merged", prs)
+	}
+	if result.Summary.MergedSuccess != 3 {
+		t.Errorf("MergedSuccess = %d, want 3", result.Summary.MergedSuccess)
+	}
+}
+
+func TestMergerResumeReportsPullRequestsClosedOutside(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 5, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha5", State: "closed", Merged: true},
+		{Number: 6, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha6", State: "closed"},
+	}
+	mock.GetPRErr["testorg/repo2"] = errors.New("bad gateway")
+
+	st := state.New(filepath.Join(t.TempDir(), "state.json"), "rebase", "testorg", []string{"deps/"})
+	st.StartPullRequest("testorg/repo1", 5)
+	st.StartPullRequest("testorg/repo1", 6)
+	st.StartPullRequest("testorg/repo2", 7)
+
+	m := New(mock, &config.Config{Org: "testorg", SourceBranches: []string{"deps/"}, SourceBranch: "deps/", Rebase: true}, nil)
+	m.SetState(st)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	prs := result.Repositories[0].PullRequests
+	if len(prs) != 2 {
+		t.Fatalf("repo1 PRs = %+v, want #5 and #6", prs)
+	}
+	for _, pr := range prs {
+		if pr.Action != output.ActionSkipClosedOutside || pr.SkipReason != output.ReasonClosedOutside {
+			t.Errorf("PR #%d = (%q, %q), want skipped as closed outside ghprmerge", pr.Number, pr.Action, pr.Reason)
+		}
+	}
+	if !strings.HasPrefix(prs[0].Reason, "merged outside ghprmerge") {
+		t.Errorf("PR #5 reason = %q, want merged outside ghprmerge", prs[0].Reason)
+	}
+	if result.Summary.MergedSuccess != 0 {
+		t.Errorf("MergedSuccess = %d, want 0 for a rebase run", result.Summary.MergedSuccess)
+	}
+
+	prs = result.Repositories[1].PullRequests
+	if len(prs) != 1 || prs[0].Number != 7 || prs[0].Action != output.ActionSkipAPIError {
+		t.Errorf("repo2 PRs = %+v, want #7 reported as an API error", prs)
+	}
+}
+
+func TestMergerSelectionActsOnExactPullRequests(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{
+		{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"},
+		{Name: "repo2", FullName: "testorg/repo2", DefaultBranch: "main"},
+	}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "dependabot/npm/lodash-4.17.21", BaseBranch: "main", HeadSHA: "sha1"},
+		{Number: 2, HeadBranch: "dependabot/npm/lodash-4.17.22", BaseBranch: "main", HeadSHA: "sha2"},
+	}
+	mock.PullRequests["testorg/repo2"] = []github.PullRequest{
+		{Number: 3, HeadBranch: "dependabot/npm/lodash-4.17.21", BaseBranch: "main", HeadSHA: "sha3"},
+	}
+
+	m := New(mock, &config.Config{
+		Org:          "testorg",
+		Close:        true,
+		FromReport:   "report.json",
+		ReportGroups: []string{"dependabot/npm/lodash-4.17.21"},
+	}, nil)
+	m.SetSelection(map[string][]int{"repo1": {1}})
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if want := []string{"testorg/repo1/" + string(rune(1))}; !reflect.DeepEqual(mock.CloseCalls, want) {
+		t.Errorf("CloseCalls = %q, want %q", mock.CloseCalls, want)
+	}
+	if result.Metadata.SourceBranch != "dependabot/npm/lodash-4.17.21" {
+		t.Errorf("SourceBranch = %q, want the report group", result.Metadata.SourceBranch)
+	}
+	if len(result.Repositories) != 1 || result.Repositories[0].Name != "repo1" {
+		t.Errorf("repositories = %+v, want only repo1", result.Repositories)
+	}
+}
+
+func TestMergerAgeFilters(t *testing.T) {
+	now := time.Now()
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "deps/a", BaseBranch: "main", HeadSHA: "sha1", CreatedAt: now.Add(-2 * time.Hour)},
+		{Number: 2, HeadBranch: "deps/b", BaseBranch: "main", HeadSHA: "sha2", CreatedAt: now.Add(-10 * 24 * time.Hour)},
+		{Number: 3, HeadBranch: "deps/c", BaseBranch: "main", HeadSHA: "sha3", CreatedAt: now.Add(-60 * 24 * time.Hour)},
+		{Number: 4, HeadBranch: "deps/d", BaseBranch: "main", HeadSHA: "sha4"},
+	}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"deps/"},
+		SourceBranch:   "deps/",
+		MinAge:         24 * time.Hour,
+		MaxAge:         30 * 24 * time.Hour,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	prs := result.Repositories[0].PullRequests
+	if len(prs) != 1 || prs[0].Number != 2 {
+		t.Fatalf("pull requests = %+v, want only #2", prs)
+	}
+}
+
+func TestMergerCloseSuperseded(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, Title: "Bump lodash from 4.17.19 to 4.17.20", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.20", HeadRepoFullName: "testorg/repo1", BaseBranch: "main"},
+		{Number: 2, Title: "Bump lodash from 4.17.19 to 4.17.21", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.21", HeadRepoFullName: "testorg/repo1", BaseBranch: "main"},
+		{Number: 3, Title: "Bump lodash from 4.17.19 to 4.17.9", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.9", HeadRepoFullName: "testorg/repo1", BaseBranch: "main"},
+		{Number: 4, Title: "Bump lodash from 4.17.19 to 4.17.20 in /frontend", HeadBranch: "dependabot/npm_and_yarn/frontend/lodash-4.17.20", HeadRepoFullName: "testorg/repo1", BaseBranch: "main"},
+		{Number: 5, Title: "Bump the npm-deps group with 3 updates", HeadBranch: "dependabot/npm_and_yarn/npm-deps-5f2a1b", HeadRepoFullName: "testorg/repo1", BaseBranch: "main"},
+	}
+
+	m := New(mock, &config.Config{
+		Org:                "testorg",
+		SourceBranches:     []string{"dependabot/"},
+		SourceBranch:       "dependabot/",
+		Close:              true,
+		DeleteSourceBranch: true,
+		Superseded:         true,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	key := func(n int) string { return "testorg/repo1/" + string(rune(n)) }
+	if want := []string{key(1), key(3)}; !reflect.DeepEqual(mock.CloseCalls, want) {
+		t.Fatalf("CloseCalls = %q, want %q", mock.CloseCalls, want)
+	}
+	if want := []string{"Superseded by #2, which updates lodash to 4.17.21. Closed by ghprmerge."}; !reflect.DeepEqual(mock.PostedComments[key(3)], want) {
+		t.Errorf("comments on #3 = %q, want %q", mock.PostedComments[key(3)], want)
+	}
+	if want := []string{"testorg/repo1/dependabot/npm_and_yarn/lodash-4.17.20", "testorg/repo1/dependabot/npm_and_yarn/lodash-4.17.9"}; !reflect.DeepEqual(mock.DeleteBranchCalls, want) {
+		t.Errorf("DeleteBranchCalls = %v, want %v", mock.DeleteBranchCalls, want)
+	}
+	prs := result.Repositories[0].PullRequests
+	if len(prs) != 2 || prs[0].Action != output.ActionClosed || prs[0].Reason != "superseded by #2, successfully closed and source branch deleted" {
+		t.Errorf("pull requests = %+v, want #1 and #3 closed as superseded by #2", prs)
+	}
+}
+
+func TestMergerCloseSupersededCommentFailure(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, Title: "Bump lodash from 4.17.19 to 4.17.20", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.20", BaseBranch: "main"},
+		{Number: 2, Title: "Bump lodash from 4.17.19 to 4.17.21", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.21", BaseBranch: "main"},
+	}
+	mock.PostCommentErr["testorg/repo1/"+string(rune(1))] = errors.New("forbidden")
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"dependabot/"},
+		SourceBranch:   "dependabot/",
+		Close:          true,
+		Superseded:     true,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(mock.CloseCalls) != 0 {
+		t.Errorf("CloseCalls = %q, want none after the comment failed", mock.CloseCalls)
+	}
+	if got := result.Repositories[0].PullRequests[0]; got.Action != output.ActionCloseFailed {
+		t.Errorf("result = %+v, want close failed", got)
+	}
+}
+
+func TestMergerCloseSupersededSkipsAmbiguousUpdates(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		// The directory of #2 cannot be told from its branch alone.
+		{Number: 1, Title: "Bump lodash from 4.17.19 to 4.17.20", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.20", BaseBranch: "main"},
+		{Number: 2, Title: "Update lodash for frontend", HeadBranch: "dependabot/npm_and_yarn/frontend/lodash-4.17.21", BaseBranch: "main"},
+		// A [Security] title carries the directory in the branch.
+		{Number: 3, Title: "[Security] Bump lodash from 4.17.19 to 4.17.21", HeadBranch: "dependabot/npm_and_yarn/frontend/lodash-4.17.21", BaseBranch: "main"},
+		// Renovate opens one PR per major version on purpose.
+		{Number: 4, Title: "Update dependency lodash to v4.17.21", HeadBranch: "renovate/lodash-4.x", BaseBranch: "main"},
+		{Number: 5, Title: "Update dependency lodash to v5", HeadBranch: "renovate/lodash-5.x", BaseBranch: "main"},
+		// A prerelease ranks below its release.
+		{Number: 6, Title: "Bump react from 18.3.1 to 19.0.0", HeadBranch: "dependabot/npm_and_yarn/react-19.0.0", BaseBranch: "main"},
+		{Number: 7, Title: "Bump react from 18.3.1 to 19.0.0-rc.1", HeadBranch: "dependabot/npm_and_yarn/react-19.0.0-rc.1", BaseBranch: "main"},
+	}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"dependabot/", "renovate/"},
+		SourceBranch:   "dependabot/",
+		Close:          true,
+		Superseded:     true,
+	}, nil)
+	if _, err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if want := []string{"testorg/repo1/" + string(rune(7))}; !reflect.DeepEqual(mock.CloseCalls, want) {
+		t.Errorf("CloseCalls = %q, want only the prerelease #7", mock.CloseCalls)
+	}
+}
+
+func TestMergerCloseStale(t *testing.T) {
+	now := time.Now()
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "feature/idle", HeadSHA: "sha1", BaseBranch: "main", UpdatedAt: now.Add(-45 * 24 * time.Hour)},
+		{Number: 2, HeadBranch: "feature/broken", HeadSHA: "sha2", BaseBranch: "main", UpdatedAt: now.Add(-time.Hour)},
+		{Number: 3, HeadBranch: "feature/conflict", HeadSHA: "sha3", BaseBranch: "main", UpdatedAt: now.Add(-time.Hour)},
+		{Number: 4, HeadBranch: "feature/active", HeadSHA: "sha4", BaseBranch: "main", UpdatedAt: now.Add(-time.Hour)},
+		{Number: 5, HeadBranch: "feature/new-failure", HeadSHA: "sha5", BaseBranch: "main", UpdatedAt: now.Add(-time.Hour)},
+	}
+	mock.CheckStatuses["testorg/repo1/sha2"] = &github.CheckStatus{Details: "1 check failing", FailingSince: now.Add(-20 * 24 * time.Hour)}
+	mock.CheckStatuses["testorg/repo1/sha5"] = &github.CheckStatus{Details: "1 check failing", FailingSince: now.Add(-2 * 24 * time.Hour)}
+	mock.BranchStatuses["testorg/repo1/"+string(rune(3))] = &github.BranchStatus{HasConflict: true}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"feature/"},
+		SourceBranch:   "feature/",
+		Close:          true,
+		Stale:          true,
+		InactiveFor:    30 * 24 * time.Hour,
+		FailingFor:     14 * 24 * time.Hour,
+		Conflicted:     true,
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	key := func(n int) string { return "testorg/repo1/" + string(rune(n)) }
+	if want := []string{key(1), key(2), key(3)}; !reflect.DeepEqual(mock.CloseCalls, want) {
+		t.Fatalf("CloseCalls = %q, want %q", mock.CloseCalls, want)
+	}
+	if want := []string{"Closing this pull request as stale: no activity for 45d. Closed by ghprmerge."}; !reflect.DeepEqual(mock.PostedComments[key(1)], want) {
+		t.Errorf("comments on #1 = %q, want %q", mock.PostedComments[key(1)], want)
+	}
+
+	prs := result.Repositories[0].PullRequests
+	wantReasons := []string{
+		"stale (no activity for 45d), successfully closed",
+		"stale (checks failing for 20d), successfully closed",
+		"stale (merge conflict), successfully closed",
+		"not stale: updated 1h ago, checks not failing, no merge conflict",
+		"not stale: updated 1h ago, checks failing for 2d, no merge conflict",
+	}
+	for i, want := range wantReasons {
+		if prs[i].Reason != want {
+			t.Errorf("PR #%d reason = %q, want %q", prs[i].Number, prs[i].Reason, want)
+		}
+	}
+	if prs[3].Action != output.ActionSkipNotStale || prs[3].SkipReason != output.ReasonNotStale {
+		t.Errorf("PR #4 = %+v, want skipped as not stale", prs[3])
+	}
+}
+
+func TestMergerCloseStaleConfirmScan(t *testing.T) {
+	mock := github.NewMockClient()
+	mock.Repositories = []github.Repository{{Name: "repo1", FullName: "testorg/repo1", DefaultBranch: "main"}}
+	mock.PullRequests["testorg/repo1"] = []github.PullRequest{
+		{Number: 1, HeadBranch: "feature/conflict", HeadSHA: "sha1", BaseBranch: "main"},
+	}
+	mock.BranchStatuses["testorg/repo1/"+string(rune(1))] = &github.BranchStatus{HasConflict: true}
+
+	m := New(mock, &config.Config{
+		Org:            "testorg",
+		SourceBranches: []string{"feature/"},
+		SourceBranch:   "feature/",
+		Close:          true,
+		Confirm:        true,
+		Stale:          true,
+		Conflicted:     true,
+		CloseComment:   "Closing: {reason}.",
+	}, nil)
+	result, err := m.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	pr := result.Repositories[0].PullRequests[0]
+	if pr.Action != output.ActionWouldClose || pr.Reason != "stale (merge conflict), would comment and close" {
+		t.Fatalf("scan result = %+v, want would close as stale", pr)
+	}
+
+	if _, err := m.RunWithActions(context.Background(), result); err != nil {
+		t.Fatalf("RunWithActions() error = %v", err)
+	}
+	if want := []string{"Closing: merge conflict."}; !reflect.DeepEqual(mock.PostedComments["testorg/repo1/"+string(rune(1))], want) {
+		t.Errorf("comments = %q, want %q", mock.PostedComments["testorg/repo1/"+string(rune(1))], want)
+	}
+	if got := result.Repositories[0].PullRequests[0]; got.Action != output.ActionClosed {
+		t.Errorf("result = %+v, want closed", got)
+	}
+}
@@ -5,14 +5,19 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
 )
 
 // RunReport executes the report mode: discovers open PRs across repositories,
-// groups them by exact source branch name, filters and sorts the results.
+// groups them by exact source branch name (or the --group-by value), filters
+// and sorts the results. The stale view instead lists the PRs open or failing
+// longer than --stale-after, oldest first.
 func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
+	startTime := time.Now()
+
 	// Discover repositories (reuses existing repo scan logic)
 	repos, err := m.discoverRepositories(ctx)
 	if err != nil {
@@ -32,9 +37,10 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 
 	// Collect all open PRs from all repositories
 	type prEntry struct {
-		repoName   string
-		pr         gh.PullRequest
-		checkState string
+		repoName     string
+		pr           gh.PullRequest
+		status       string
+		failingSince time.Time
 	}
 
 	var allPRs []prEntry
@@ -85,8 +91,8 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 				}
 			}
 
-			// Apply author filter if specified
-			if m.config.Author != "" && pr.Author != m.config.Author {
+			// Apply author, label, and age filters if specified
+			if !m.matchesFilters(pr) {
 				continue
 			}
 
@@ -102,54 +108,106 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 		m.console.FinishProgress()
 	}
 
-	// Group PRs by exact source branch name
-	type groupEntry struct {
-		branch string
-		prs    []prEntry
+	// Determine verbosity
+	verbosity := m.config.Verbosity
+	if verbosity == "" {
+		verbosity = "standard"
 	}
-	groupMap := make(map[string]*groupEntry)
-	for _, entry := range allPRs {
-		branch := entry.pr.HeadBranch
-		if g, ok := groupMap[branch]; ok {
-			g.prs = append(g.prs, entry)
-		} else {
-			groupMap[branch] = &groupEntry{
-				branch: branch,
-				prs:    []prEntry{entry},
+
+	// Evaluate status for each PR in report mode
+	// Only evaluate if we need status (standard or verbose modes, or JSON)
+	// Only text and Markdown honor brief verbosity; other formats always include status,
+	// and comparing against a previous report needs them too
+	format := m.config.OutputFormat()
+	needsStatus := verbosity != "brief" || (format != "text" && format != "markdown") || m.config.CompareReport != ""
+
+	groupBy := output.ReportGroupBy(m.config.GroupBy)
+	if groupBy == "" {
+		groupBy = output.GroupByBranch
+	}
+
+	// Grouping by status and the stale view need every PR's status, and when
+	// its checks started failing, before the groups are formed
+	staleView := m.config.View == string(output.ViewStale)
+	if groupBy == output.GroupByStatus || staleView {
+		for i := range allPRs {
+			if showProgress {
+				m.console.ProgressBar(i+1, len(allPRs), "Evaluating")
 			}
+			allPRs[i].status, allPRs[i].failingSince = m.evaluateReportStatus(ctx, reportOwner(allPRs[i].pr, m.config.Org), allPRs[i].repoName, allPRs[i].pr)
+		}
+		if showProgress && len(allPRs) > 0 {
+			m.console.FinishProgress()
 		}
+		needsStatus = false
 	}
 
-	// Filter by min-group-size
+	// Group PRs by exact source branch name, or by the --group-by value
+	type groupEntry struct {
+		branch string
+		prs    []prEntry
+	}
 	var groups []groupEntry
-	for _, g := range groupMap {
-		if len(g.prs) >= m.config.MinGroupSize {
-			groups = append(groups, *g)
+	belowMinimum := make(map[string]int)
+	if staleView {
+		// The stale view is one group of PRs open or failing longer than
+		// --stale-after, ordered by the earlier of the two times
+		stale := groupEntry{branch: output.StaleGroup}
+		now := time.Now()
+		for _, entry := range allPRs {
+			if output.IsStale(entry.pr.CreatedAt, entry.failingSince, m.config.ReportStaleAfter(), now) {
+				stale.prs = append(stale.prs, entry)
+			}
+		}
+		sort.SliceStable(stale.prs, func(i, j int) bool {
+			return output.StaleSince(stale.prs[i].pr.CreatedAt, stale.prs[i].failingSince).
+				Before(output.StaleSince(stale.prs[j].pr.CreatedAt, stale.prs[j].failingSince))
+		})
+		if len(stale.prs) > 0 {
+			groups = append(groups, stale)
+		}
+	} else {
+		groupMap := make(map[string]*groupEntry)
+		for _, entry := range allPRs {
+			branch := reportGroupKey(groupBy, entry.pr, entry.status)
+			if g, ok := groupMap[branch]; ok {
+				g.prs = append(g.prs, entry)
+			} else {
+				groupMap[branch] = &groupEntry{
+					branch: branch,
+					prs:    []prEntry{entry},
+				}
+			}
 		}
-	}
 
-	// Sort: descending count, then ascending branch name for ties
-	sort.Slice(groups, func(i, j int) bool {
-		if len(groups[i].prs) != len(groups[j].prs) {
-			return len(groups[i].prs) > len(groups[j].prs)
+		// Filter by min-group-size
+		for _, g := range groupMap {
+			if len(g.prs) >= m.config.MinGroupSize {
+				groups = append(groups, *g)
+			} else {
+				belowMinimum[g.branch] = len(g.prs)
+			}
 		}
-		return groups[i].branch < groups[j].branch
-	})
 
-	// Determine verbosity
-	verbosity := m.config.Verbosity
-	if verbosity == "" {
-		verbosity = "standard"
+		// Sort: descending count, then ascending branch name for ties
+		sort.Slice(groups, func(i, j int) bool {
+			if len(groups[i].prs) != len(groups[j].prs) {
+				return len(groups[i].prs) > len(groups[j].prs)
+			}
+			return groups[i].branch < groups[j].branch
+		})
 	}
 
-	// Evaluate status for each PR in report mode
-	// Only evaluate if we need status (standard or verbose modes, or JSON)
-	needsStatus := verbosity != "brief" || m.config.JSON
-
 	// Build report result
 	result := &output.ReportResult{
-		Groups: make([]output.ReportGroup, 0, len(groups)),
+		Metadata:     m.ReportMetadata(),
+		Groups:       make([]output.ReportGroup, 0, len(groups)),
+		BelowMinimum: belowMinimum,
 	}
+	result.Metadata.ReposScanned = repoCount
+	result.Metadata.StartTime = startTime
+	// Groups other than branches, including the stale group, are named by Key
+	keyed := groupBy != output.GroupByBranch || staleView
 
 	if showProgress && needsStatus && len(groups) > 0 {
 		// Count total PRs that need evaluation
@@ -160,11 +218,7 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 		evalCount := 0
 
 		for _, g := range groups {
-			rg := output.ReportGroup{
-				SourceBranch: g.branch,
-				Count:        len(g.prs),
-				PullRequests: make([]output.ReportPullRequest, 0, len(g.prs)),
-			}
+			rg := newReportGroup(keyed, g.branch, len(g.prs))
 
 			for _, entry := range g.prs {
 				evalCount++
@@ -180,14 +234,14 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 		m.console.FinishProgress()
 	} else {
 		for _, g := range groups {
-			rg := output.ReportGroup{
-				SourceBranch: g.branch,
-				Count:        len(g.prs),
-				PullRequests: make([]output.ReportPullRequest, 0, len(g.prs)),
-			}
+			rg := newReportGroup(keyed, g.branch, len(g.prs))
 
 			for _, entry := range g.prs {
 				rpr := m.buildReportPR(ctx, entry.repoName, entry.pr, needsStatus, verbosity)
+				if entry.status != "" {
+					rpr.Status = entry.status
+					rpr.FailingSince = entry.failingSince
+				}
 				rg.PullRequests = append(rg.PullRequests, rpr)
 			}
 
@@ -195,9 +249,39 @@ func (m *Merger) RunReport(ctx context.Context) (*output.ReportResult, error) {
 		}
 	}
 
+	result.Summary = output.SummarizeReport(result.Groups)
+	result.Metadata.EndTime = time.Now()
 	return result, nil
 }
 
+// ReportMetadata returns the report metadata that follows from the
+// configuration: the organization, grouping, and every filter that decides
+// which PRs are listed. RunReport adds the scan counts and times.
+func (m *Merger) ReportMetadata() *output.ReportMetadata {
+	metadata := &output.ReportMetadata{
+		Org:                  m.config.Org,
+		SourceBranchPrefixes: m.config.SourceBranchPrefix,
+		MinGroupSize:         m.config.MinGroupSize,
+		Author:               m.config.Author,
+		Labels:               m.config.Labels,
+		ExcludeLabels:        m.config.ExcludeLabels,
+		Repos:                m.config.Repos,
+		RepoLimit:            m.config.RepoLimit,
+		GroupBy:              m.config.GroupBy,
+		View:                 m.config.View,
+	}
+	if m.config.MinAge > 0 {
+		metadata.MinAge = output.FormatAge(m.config.MinAge)
+	}
+	if m.config.MaxAge > 0 {
+		metadata.MaxAge = output.FormatAge(m.config.MaxAge)
+	}
+	if m.config.View == string(output.ViewStale) || m.config.CompareReport != "" {
+		metadata.StaleAfter = output.FormatAge(m.config.ReportStaleAfter())
+	}
+	return metadata
+}
+
 // buildReportPR builds a ReportPullRequest from a PR entry.
 func (m *Merger) buildReportPR(ctx context.Context, repoName string, pr gh.PullRequest, needsStatus bool, verbosity string) output.ReportPullRequest {
 	rpr := output.ReportPullRequest{
@@ -205,6 +289,8 @@ func (m *Merger) buildReportPR(ctx context.Context, repoName string, pr gh.PullR
 		Number:     pr.Number,
 		Title:      pr.Title,
 		URL:        pr.URL,
+		CreatedAt:  pr.CreatedAt,
+		UpdatedAt:  pr.UpdatedAt,
 	}
 
 	if !needsStatus {
@@ -212,39 +298,84 @@ func (m *Merger) buildReportPR(ctx context.Context, repoName string, pr gh.PullR
 	}
 
 	// Evaluate status using the same logic as ghprmerge's evaluatePullRequest
-	owner := strings.Split(pr.RepoFullName, "/")[0]
-	if owner == "" {
-		// Fallback: extract owner from the org config
-		owner = m.config.Org
+	rpr.Status, rpr.FailingSince = m.evaluateReportStatus(ctx, reportOwner(pr, m.config.Org), repoName, pr)
+	return rpr
+}
+
+// reportOwner returns the owner of the PR's repository, falling back to the
+// organization from the config.
+func reportOwner(pr gh.PullRequest, org string) string {
+	if owner := strings.Split(pr.RepoFullName, "/")[0]; owner != "" {
+		return owner
 	}
+	return org
+}
 
-	rpr.Status = m.evaluateReportStatus(ctx, owner, repoName, pr)
-	return rpr
+// reportGroupKey returns the value a PR is grouped by. PRs whose package
+// cannot be determined, such as grouped dependency updates, keep their branch
+// as the key.
+func reportGroupKey(groupBy output.ReportGroupBy, pr gh.PullRequest, status string) string {
+	switch groupBy {
+	case output.GroupByPackage:
+		if dep := gh.ParseDependency(pr); dep.Package != "" {
+			return dep.Package
+		}
+	case output.GroupByPackageVersion:
+		if dep := gh.ParseDependency(pr); dep.Package != "" && dep.Version != "" {
+			return dep.Package + "@" + dep.Version
+		}
+	case output.GroupByEcosystem:
+		if dep := gh.ParseDependency(pr); dep.Ecosystem != "" {
+			return dep.Ecosystem
+		}
+		return "unknown"
+	case output.GroupByAuthor:
+		return pr.Author
+	case output.GroupByStatus:
+		return status
+	}
+	return pr.HeadBranch
 }
 
-// evaluateReportStatus evaluates the status of a PR for report mode.
-// It reuses the same assessment logic as ghprmerge's normal evaluation.
-func (m *Merger) evaluateReportStatus(ctx context.Context, owner, repoName string, pr gh.PullRequest) string {
+// newReportGroup starts a report group. Branch groups keep the branch in
+// SourceBranch; other groups store their name in Key.
+func newReportGroup(keyed bool, key string, count int) output.ReportGroup {
+	group := output.ReportGroup{
+		Count:        count,
+		PullRequests: make([]output.ReportPullRequest, 0, count),
+	}
+	if keyed {
+		group.Key = key
+	} else {
+		group.SourceBranch = key
+	}
+	return group
+}
+
+// evaluateReportStatus evaluates the status of a PR for report mode, and when
+// its checks are failing, since when. It reuses the same assessment logic as
+// ghprmerge's normal evaluation.
+func (m *Merger) evaluateReportStatus(ctx context.Context, owner, repoName string, pr gh.PullRequest) (string, time.Time) {
 	// Get check status
 	checkStatus, err := m.client.GetCheckStatus(ctx, owner, repoName, pr.HeadSHA)
 	if err != nil {
-		return "error"
+		return "error", time.Time{}
 	}
 
 	if checkStatus.NoChecks {
 		// No checks configured - check branch status
-		return m.evaluateReportBranchStatus(ctx, owner, repoName, pr, "no checks configured")
+		return m.evaluateReportBranchStatus(ctx, owner, repoName, pr, "no checks configured"), time.Time{}
 	}
 
 	if checkStatus.Pending {
-		return "checks pending"
+		return "checks pending", time.Time{}
 	}
 
 	if !checkStatus.AllPassing {
-		return "checks failing"
+		return "checks failing", checkStatus.FailingSince
 	}
 
-	return m.evaluateReportBranchStatus(ctx, owner, repoName, pr, "passing")
+	return m.evaluateReportBranchStatus(ctx, owner, repoName, pr, "passing"), time.Time{}
 }
 
 // evaluateReportBranchStatus evaluates branch status for report mode.
@@ -3,9 +3,11 @@ package merger
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/config"
 	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
 )
 
 func TestRunReportGroupsByExactBranch(t *testing.T) {
@@ -60,6 +62,13 @@ func TestRunReportGroupsByExactBranch(t *testing.T) {
 	if result.Groups[1].Count != 2 {
 		t.Errorf("expected second group count = 2, got %d", result.Groups[1].Count)
 	}
+
+	if result.Summary == nil || result.Summary.TotalGroups != 2 || result.Summary.TotalPullRequests != 5 {
+		t.Fatalf("Summary = %+v, want 2 groups and 5 PRs", result.Summary)
+	}
+	if len(result.Summary.Groups) != 2 || result.Summary.Groups[0].Group != "dependabot/go_modules/foo-1.2.3" {
+		t.Errorf("Summary.Groups = %+v, want one entry per group in report order", result.Summary.Groups)
+	}
 }
 
 func TestRunReportMinGroupSize(t *testing.T) {
@@ -439,6 +448,60 @@ func TestRunReportStatusEvaluation(t *testing.T) {
 	}
 }
 
+func TestRunReportHTMLEvaluatesStatusAndMetadata(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.Repositories = []gh.Repository{
+		{Name: "repo-a", FullName: "myorg/repo-a", DefaultBranch: "main"},
+		{Name: "repo-b", FullName: "myorg/repo-b", DefaultBranch: "main"},
+	}
+	mock.PullRequests["myorg/repo-a"] = []gh.PullRequest{
+		{Number: 1, HeadBranch: "branch-x", BaseBranch: "main", HeadSHA: "sha-passing", RepoFullName: "myorg/repo-a"},
+	}
+	mock.PullRequests["myorg/repo-b"] = []gh.PullRequest{
+		{Number: 2, HeadBranch: "branch-x", BaseBranch: "main", HeadSHA: "sha-passing", RepoFullName: "myorg/repo-b"},
+	}
+	mock.CheckStatuses["myorg/repo-a/sha-passing"] = &gh.CheckStatus{AllPassing: true}
+	mock.CheckStatuses["myorg/repo-b/sha-passing"] = &gh.CheckStatus{AllPassing: true}
+
+	cfg := &config.Config{
+		Org:                "myorg",
+		Report:             true,
+		MinGroupSize:       2,
+		SourceBranchPrefix: []string{"branch-"},
+		Verbosity:          "brief",
+		Format:             "html",
+	}
+
+	m := New(mock, cfg, nil)
+	result, err := m.RunReport(context.Background())
+	if err != nil {
+		t.Fatalf("RunReport() error = %v", err)
+	}
+
+	if len(result.Groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(result.Groups))
+	}
+	for _, pr := range result.Groups[0].PullRequests {
+		if pr.Status != "passing" {
+			t.Errorf("expected %s #%d status = passing in brief HTML report, got %q", pr.Repository, pr.Number, pr.Status)
+		}
+	}
+
+	meta := result.Metadata
+	if meta == nil {
+		t.Fatal("expected report metadata")
+	}
+	if meta.Org != "myorg" || meta.MinGroupSize != 2 || meta.ReposScanned != 2 {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if len(meta.SourceBranchPrefixes) != 1 || meta.SourceBranchPrefixes[0] != "branch-" {
+		t.Errorf("expected source branch prefixes [branch-], got %v", meta.SourceBranchPrefixes)
+	}
+	if meta.StartTime.IsZero() || meta.EndTime.Before(meta.StartTime) {
+		t.Errorf("expected start and end times, got %v - %v", meta.StartTime, meta.EndTime)
+	}
+}
+
 func TestRunReportNonDefaultBranchFiltered(t *testing.T) {
 	mock := gh.NewMockClient()
 	mock.Repositories = []gh.Repository{
@@ -470,3 +533,155 @@ func TestRunReportNonDefaultBranchFiltered(t *testing.T) {
 		t.Fatalf("expected 0 groups (non-default branch filtered), got %d", len(result.Groups))
 	}
 }
+
+func TestRunReportLabelFilters(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.Repositories = []gh.Repository{
+		{Name: "repo-a", FullName: "myorg/repo-a", DefaultBranch: "main"},
+		{Name: "repo-b", FullName: "myorg/repo-b", DefaultBranch: "main"},
+		{Name: "repo-c", FullName: "myorg/repo-c", DefaultBranch: "main"},
+	}
+	mock.PullRequests["myorg/repo-a"] = []gh.PullRequest{
+		{Number: 1, HeadBranch: "branch-a", BaseBranch: "main", HeadSHA: "sha1", RepoFullName: "myorg/repo-a", Labels: []string{"dependencies"}},
+	}
+	mock.PullRequests["myorg/repo-b"] = []gh.PullRequest{
+		{Number: 2, HeadBranch: "branch-a", BaseBranch: "main", HeadSHA: "sha2", RepoFullName: "myorg/repo-b", Labels: []string{"dependencies"}},
+	}
+	mock.PullRequests["myorg/repo-c"] = []gh.PullRequest{
+		{Number: 3, HeadBranch: "branch-a", BaseBranch: "main", HeadSHA: "sha3", RepoFullName: "myorg/repo-c", Labels: []string{"dependencies", "on-hold"}},
+	}
+
+	cfg := &config.Config{
+		Org:           "myorg",
+		Report:        true,
+		MinGroupSize:  2,
+		JSON:          true,
+		Labels:        []string{"dependencies"},
+		ExcludeLabels: []string{"on-hold"},
+	}
+
+	m := New(mock, cfg, nil)
+	result, err := m.RunReport(context.Background())
+	if err != nil {
+		t.Fatalf("RunReport() error = %v", err)
+	}
+
+	if len(result.Groups) != 1 || len(result.Groups[0].PullRequests) != 2 {
+		t.Fatalf("groups = %+v, want one group with 2 PRs", result.Groups)
+	}
+}
+
+func TestRunReportGroupBy(t *testing.T) {
+	mock := gh.NewMockClient()
+	mock.Repositories = []gh.Repository{
+		{Name: "repo-a", FullName: "myorg/repo-a", DefaultBranch: "main"},
+		{Name: "repo-b", FullName: "myorg/repo-b", DefaultBranch: "main"},
+		{Name: "repo-c", FullName: "myorg/repo-c", DefaultBranch: "main"},
+	}
+	mock.PullRequests["myorg/repo-a"] = []gh.PullRequest{
+		{Number: 1, Title: "Bump lodash from 4.17.20 to 4.17.21", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.21", BaseBranch: "main", HeadSHA: "sha1", Author: "dependabot[bot]", RepoFullName: "myorg/repo-a"},
+		{Number: 2, Title: "Bump actions/checkout from 3 to 4", HeadBranch: "dependabot/github_actions/actions/checkout-4", BaseBranch: "main", HeadSHA: "sha2", Author: "dependabot[bot]", RepoFullName: "myorg/repo-a"},
+	}
+	mock.PullRequests["myorg/repo-b"] = []gh.PullRequest{
+		{Number: 3, Title: "Bump lodash from 4.17.20 to 4.17.21 in /frontend", HeadBranch: "dependabot/npm_and_yarn/frontend/lodash-4.17.21", BaseBranch: "main", HeadSHA: "sha3", Author: "dependabot[bot]", RepoFullName: "myorg/repo-b"},
+	}
+	mock.PullRequests["myorg/repo-c"] = []gh.PullRequest{
+		{Number: 4, Title: "Bump lodash from 4.17.19 to 4.17.20", HeadBranch: "dependabot/npm_and_yarn/lodash-4.17.20", BaseBranch: "main", HeadSHA: "sha4", Author: "dependabot[bot]", RepoFullName: "myorg/repo-c"},
+		{Number: 5, Title: "Update dependency lodash to v4.17.21", HeadBranch: "renovate/lodash-4.x", BaseBranch: "main", HeadSHA: "sha5", Author: "renovate[bot]", RepoFullName: "myorg/repo-c"},
+	}
+	mock.CheckStatuses["myorg/repo-a/sha1"] = &gh.CheckStatus{AllPassing: true}
+	mock.CheckStatuses["myorg/repo-a/sha2"] = &gh.CheckStatus{AllPassing: false}
+	mock.CheckStatuses["myorg/repo-b/sha3"] = &gh.CheckStatus{AllPassing: true}
+	mock.CheckStatuses["myorg/repo-c/sha4"] = &gh.CheckStatus{AllPassing: false}
+	mock.CheckStatuses["myorg/repo-c/sha5"] = &gh.CheckStatus{AllPassing: true}
+
+	tests := []struct {
+		groupBy string
+		want    map[string]int
+	}{
+		{"package", map[string]int{"lodash": 4}},
+		{"package-version", map[string]int{"lodash@4.17.21": 3}},
+		{"ecosystem", map[string]int{"npm_and_yarn": 3}},
+		{"author", map[string]int{"dependabot[bot]": 4}},
+		{"status", map[string]int{"passing": 3, "checks failing": 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.groupBy, func(t *testing.T) {
+			cfg := &config.Config{Org: "myorg", Report: true, MinGroupSize: 2, JSON: true, GroupBy: tt.groupBy}
+			result, err := New(mock, cfg, nil).RunReport(context.Background())
+			if err != nil {
+				t.Fatalf("RunReport() error = %v", err)
+			}
+			if result.Metadata.GroupBy != tt.groupBy {
+				t.Errorf("Metadata.GroupBy = %q, want %q", result.Metadata.GroupBy, tt.groupBy)
+			}
+			got := make(map[string]int)
+			for _, group := range result.Groups {
+				if group.SourceBranch != "" {
+					t.Errorf("group %q has SourceBranch %q, want it empty", group.Key, group.SourceBranch)
+				}
+				got[group.Key] = group.Count
+				for _, pr := range group.PullRequests {
+					if pr.Status == "" {
+						t.Errorf("PR #%d has no status", pr.Number)
+					}
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("groups = %v, want %v", got, tt.want)
+			}
+			for key, count := range tt.want {
+				if got[key] != count {
+					t.Errorf("group %q count = %d, want %d", key, got[key], count)
+				}
+			}
+		})
+	}
+}
+
+func TestRunReportStaleView(t *testing.T) {
+	now := time.Now()
+	mock := gh.NewMockClient()
+	mock.Repositories = []gh.Repository{
+		{Name: "repo-a", FullName: "myorg/repo-a", DefaultBranch: "main"},
+		{Name: "repo-b", FullName: "myorg/repo-b", DefaultBranch: "main"},
+	}
+	mock.PullRequests["myorg/repo-a"] = []gh.PullRequest{
+		{Number: 1, HeadBranch: "dependabot/foo-1.0", BaseBranch: "main", HeadSHA: "sha1", RepoFullName: "myorg/repo-a", CreatedAt: now.Add(-20 * 24 * time.Hour)},
+		{Number: 2, HeadBranch: "dependabot/bar-1.0", BaseBranch: "main", HeadSHA: "sha2", RepoFullName: "myorg/repo-a", CreatedAt: now.Add(-24 * time.Hour)},
+	}
+	mock.PullRequests["myorg/repo-b"] = []gh.PullRequest{
+		{Number: 3, HeadBranch: "feature/old", BaseBranch: "main", HeadSHA: "sha3", RepoFullName: "myorg/repo-b", CreatedAt: now.Add(-90 * 24 * time.Hour)},
+		// Without a creation time, only the failing checks make #4 stale.
+		{Number: 4, HeadBranch: "feature/broken", BaseBranch: "main", HeadSHA: "sha4", RepoFullName: "myorg/repo-b"},
+	}
+	failingSince := now.Add(-15 * 24 * time.Hour)
+	mock.CheckStatuses["myorg/repo-a/sha1"] = &gh.CheckStatus{FailingSince: failingSince}
+	mock.CheckStatuses["myorg/repo-b/sha4"] = &gh.CheckStatus{FailingSince: now.Add(-30 * 24 * time.Hour)}
+
+	m := New(mock, &config.Config{
+		Org:          "myorg",
+		Report:       true,
+		MinGroupSize: 2,
+		JSON:         true,
+		View:         "stale",
+	}, nil)
+	result, err := m.RunReport(context.Background())
+	if err != nil {
+		t.Fatalf("RunReport() error = %v", err)
+	}
+
+	if len(result.Groups) != 1 || result.Groups[0].Key != output.StaleGroup {
+		t.Fatalf("groups = %+v, want one stale group", result.Groups)
+	}
+	prs := result.Groups[0].PullRequests
+	if len(prs) != 3 || prs[0].Number != 3 || prs[1].Number != 4 || prs[2].Number != 1 {
+		t.Fatalf("stale PRs = %+v, want #3, #4, then #1", prs)
+	}
+	if prs[2].Status != "checks failing" || !prs[2].FailingSince.Equal(failingSince) {
+		t.Errorf("PR #1 status = %q, failing since %v, want checks failing since %v", prs[2].Status, prs[2].FailingSince, failingSince)
+	}
+	if result.Metadata.View != "stale" || result.Metadata.StaleAfter != "14d" {
+		t.Errorf("metadata view = %q, staleAfter = %q, want stale and 14d", result.Metadata.View, result.Metadata.StaleAfter)
+	}
+}
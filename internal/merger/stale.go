@@ -0,0 +1,95 @@
+package merger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// defaultStaleComment is posted on PRs closed by --stale without --close-comment.
+const defaultStaleComment = "Closing this pull request as stale: {reason}. Closed by ghprmerge."
+
+// checkStale evaluates a PR against the --stale criteria. A stale PR's reason
+// is recorded for its closing comment and true is returned; otherwise result
+// is set to a skip that explains why the PR was kept open.
+func (m *Merger) checkStale(ctx context.Context, owner string, repo gh.Repository, pr gh.PullRequest, result *output.PullRequestResult) bool {
+	var matched, kept []string
+
+	if m.config.InactiveFor > 0 {
+		switch {
+		case pr.UpdatedAt.IsZero():
+			kept = append(kept, "last update unknown")
+		case time.Since(pr.UpdatedAt) >= m.config.InactiveFor:
+			matched = append(matched, "no activity for "+output.FormatAge(time.Since(pr.UpdatedAt)))
+		default:
+			kept = append(kept, "updated "+output.FormatAge(time.Since(pr.UpdatedAt))+" ago")
+		}
+	}
+
+	if m.config.FailingFor > 0 {
+		checkStatus, err := m.client.GetCheckStatus(ctx, owner, repo.Name, pr.HeadSHA)
+		if err != nil {
+			result.Action = output.ActionSkipAPIError
+			result.Reason = fmt.Sprintf("failed to get check status: %v", err)
+			result.SkipReason = output.ReasonAPIError
+			return false
+		}
+		switch {
+		case checkStatus.NoChecks || checkStatus.Pending || checkStatus.AllPassing:
+			kept = append(kept, "checks not failing")
+		case checkStatus.FailingSince.IsZero():
+			kept = append(kept, "checks failing for an unknown time")
+		case time.Since(checkStatus.FailingSince) >= m.config.FailingFor:
+			matched = append(matched, "checks failing for "+output.FormatAge(time.Since(checkStatus.FailingSince)))
+		default:
+			kept = append(kept, "checks failing for "+output.FormatAge(time.Since(checkStatus.FailingSince)))
+		}
+	}
+
+	if m.config.Conflicted {
+		branchStatus, err := m.client.GetBranchStatus(ctx, owner, repo.Name, pr.Number)
+		if err != nil {
+			result.Action = output.ActionSkipAPIError
+			result.Reason = fmt.Sprintf("failed to get branch status: %v", err)
+			result.SkipReason = output.ReasonAPIError
+			return false
+		}
+		if branchStatus.HasConflict {
+			matched = append(matched, "merge conflict")
+		} else {
+			kept = append(kept, "no merge conflict")
+		}
+	}
+
+	if len(matched) == 0 {
+		result.Action = output.ActionSkipNotStale
+		result.Reason = "not stale: " + strings.Join(kept, ", ")
+		result.SkipReason = output.ReasonNotStale
+		return false
+	}
+
+	if m.staleReasons == nil {
+		m.staleReasons = make(map[string]string)
+	}
+	m.staleReasons[prKey(repo.FullName, pr.Number)] = strings.Join(matched, ", ")
+	return true
+}
+
+// closeComment returns the comment to post before closing a PR: the
+// --close-comment text, or the default stale comment under --stale, with
+// {reason} replaced by the PR's stale reason. Without --stale there is no
+// reason, and the text is posted as given.
+func (m *Merger) closeComment(repoFullName string, number int) string {
+	comment := m.config.CloseComment
+	if !m.config.Stale {
+		return comment
+	}
+	if comment == "" {
+		comment = defaultStaleComment
+	}
+	return strings.ReplaceAll(comment, "{reason}", m.staleReasons[prKey(repoFullName, number)])
+}
@@ -0,0 +1,89 @@
+package merger
+
+import (
+	"fmt"
+
+	gh "github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+)
+
+// supersession records the newer pull request that makes an older one for
+// the same dependency obsolete.
+type supersession struct {
+	newer   int
+	pkg     string
+	version string
+}
+
+// comment is posted on the superseded PR before it is closed.
+func (s supersession) comment() string {
+	return fmt.Sprintf("Superseded by #%d, which updates %s to %s. Closed by ghprmerge.", s.newer, s.pkg, s.version)
+}
+
+// prKey identifies a PR in the Merger's superseded and staleReasons maps.
+func prKey(repoFullName string, number int) string {
+	return fmt.Sprintf("%s#%d", repoFullName, number)
+}
+
+// filterSuperseded keeps the candidates that another open PR in the
+// repository supersedes: one updating the same package in the same ecosystem
+// and directory to a newer version, or to the same version when it was
+// opened later. Every open PR counts as the newer one, even when filters
+// exclude it from the run. Only Dependabot PRs are considered: Renovate opens
+// separate PRs for the same package on purpose, such as one per major
+// version. PRs whose package, version, or directory cannot be determined are
+// never superseded.
+func (m *Merger) filterSuperseded(repoFullName string, open, candidates []gh.PullRequest) []gh.PullRequest {
+	type entry struct {
+		pr  gh.PullRequest
+		dep gh.Dependency
+	}
+	newest := make(map[string]entry)
+	for _, pr := range open {
+		dep, ok := supersedable(pr)
+		if !ok {
+			continue
+		}
+		key := dep.Ecosystem + "\x00" + dep.Directory + "\x00" + dep.Package
+		current, ok := newest[key]
+		if !ok {
+			newest[key] = entry{pr, dep}
+			continue
+		}
+		c := gh.CompareVersions(dep.Version, current.dep.Version)
+		if c > 0 || (c == 0 && pr.Number > current.pr.Number) {
+			newest[key] = entry{pr, dep}
+		}
+	}
+
+	var superseded []gh.PullRequest
+	for _, pr := range candidates {
+		dep, ok := supersedable(pr)
+		if !ok {
+			continue
+		}
+		latest := newest[dep.Ecosystem+"\x00"+dep.Directory+"\x00"+dep.Package]
+		if latest.pr.Number == pr.Number {
+			continue
+		}
+		if m.superseded == nil {
+			m.superseded = make(map[string]supersession)
+		}
+		m.superseded[prKey(repoFullName, pr.Number)] = supersession{
+			newer:   latest.pr.Number,
+			pkg:     latest.dep.Package,
+			version: latest.dep.Version,
+		}
+		superseded = append(superseded, pr)
+	}
+	return superseded
+}
+
+// supersedable parses the dependency of a Dependabot PR and reports whether
+// it is complete enough to group PRs for --superseded.
+func supersedable(pr gh.PullRequest) (gh.Dependency, bool) {
+	if !gh.IsDependabotBranch(pr.HeadBranch) {
+		return gh.Dependency{}, false
+	}
+	dep := gh.ParseDependency(pr)
+	return dep, dep.Package != "" && dep.Version != "" && dep.Directory != ""
+}
@@ -0,0 +1,442 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportGroupChange is a group whose PR count differs between
+// two reports. New groups have a PreviousCount of 0 and removed groups a
+// Count of 0.
+type ReportGroupChange struct {
+	Group         string `json:"group"`
+	PreviousCount int    `json:"previousCount"`
+	Count         int    `json:"count"`
+}
+
+// ReportStatusChange is a PR in the current report with its status in each
+// report. PreviousStatus is empty for a newly stale PR the previous report
+// did not include.
+type ReportStatusChange struct {
+	Group          string `json:"group"`
+	Repository     string `json:"repository"`
+	Number         int    `json:"number"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	URL            string `json:"url,omitempty"`
+}
+
+// ReportComparison describes what changed since a previously saved report.
+// NewlyStale lists the PRs that crossed the --stale-after threshold between
+// the two reports.
+type ReportComparison struct {
+	PreviousEndTime time.Time            `json:"previousEndTime,omitzero"`
+	NewGroups       []ReportGroupChange  `json:"newGroups"`
+	GrownGroups     []ReportGroupChange  `json:"grownGroups"`
+	ShrunkGroups    []ReportGroupChange  `json:"shrunkGroups"`
+	RemovedGroups   []ReportGroupChange  `json:"removedGroups"`
+	StatusChanges   []ReportStatusChange `json:"statusChanges"`
+	NewlyStale      []ReportStatusChange `json:"newlyStale"`
+}
+
+// HasChanges reports whether anything changed between the two reports.
+func (c *ReportComparison) HasChanges() bool {
+	return len(c.NewGroups)+len(c.GrownGroups)+len(c.ShrunkGroups)+len(c.RemovedGroups)+len(c.StatusChanges)+len(c.NewlyStale) > 0
+}
+
+// ReadReport reads a report saved with report --json, in either key casing.
+func ReadReport(path string) (*ReportResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous report: %w", err)
+	}
+	return parseReport(data)
+}
+
+// parseReport decodes a report JSON document. The casing is detected from
+// which form carries the group names and metadata times.
+func parseReport(data []byte) (*ReportResult, error) {
+	var camel ReportResult
+	if err := json.Unmarshal(data, &camel); err != nil {
+		return nil, fmt.Errorf("invalid report JSON: %w", err)
+	}
+	if camel.SchemaVersion > SchemaVersion {
+		return nil, fmt.Errorf("report has schema_version %d, but this version of ghprmerge reads %d", camel.SchemaVersion, SchemaVersion)
+	}
+	if hasGroupNames(camel.Groups) || (camel.Metadata != nil && !camel.Metadata.StartTime.IsZero()) {
+		return &camel, nil
+	}
+
+	var snake reportResultSnake
+	if err := json.Unmarshal(data, &snake); err != nil {
+		return nil, fmt.Errorf("invalid report JSON: %w", err)
+	}
+	result := &ReportResult{SchemaVersion: snake.SchemaVersion, Groups: make([]ReportGroup, 0, len(snake.Groups))}
+	if snake.Metadata != nil {
+		metadata := ReportMetadata(*snake.Metadata)
+		result.Metadata = &metadata
+	}
+	for _, group := range snake.Groups {
+		result.Groups = append(result.Groups, group.camelCase())
+	}
+	return result, nil
+}
+
+func hasGroupNames(groups []ReportGroup) bool {
+	for _, group := range groups {
+		if group.Name() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareReports returns what changed from previous to current. PRs are
+// matched by repository and number; statuses are only compared when both
+// reports include them. A group that fell below --min-group-size is reported
+// as shrunk rather than removed. A PR is newly stale when it had been open or
+// failing for staleAfter when the current report ended but not when the
+// previous one did, which is only known when the previous report recorded
+// its end time.
+func CompareReports(previous, current *ReportResult, staleAfter time.Duration) *ReportComparison {
+	comparison := &ReportComparison{
+		NewGroups:     []ReportGroupChange{},
+		GrownGroups:   []ReportGroupChange{},
+		ShrunkGroups:  []ReportGroupChange{},
+		RemovedGroups: []ReportGroupChange{},
+		StatusChanges: []ReportStatusChange{},
+		NewlyStale:    []ReportStatusChange{},
+	}
+	if previous.Metadata != nil {
+		comparison.PreviousEndTime = previous.Metadata.EndTime
+	}
+	now := time.Now()
+	if current.Metadata != nil && !current.Metadata.EndTime.IsZero() {
+		now = current.Metadata.EndTime
+	}
+
+	previousCounts := make(map[string]int)
+	previousPRs := make(map[string]ReportPullRequest)
+	for _, group := range previous.Groups {
+		previousCounts[group.Name()] = group.Count
+		for _, pr := range group.PullRequests {
+			previousPRs[reportPRKey(pr)] = pr
+		}
+	}
+
+	currentCounts := make(map[string]bool)
+	for _, group := range current.Groups {
+		currentCounts[group.Name()] = true
+		change := ReportGroupChange{Group: group.Name(), PreviousCount: previousCounts[group.Name()], Count: group.Count}
+		switch _, seen := previousCounts[group.Name()]; {
+		case !seen:
+			comparison.NewGroups = append(comparison.NewGroups, change)
+		case change.Count > change.PreviousCount:
+			comparison.GrownGroups = append(comparison.GrownGroups, change)
+		case change.Count < change.PreviousCount:
+			comparison.ShrunkGroups = append(comparison.ShrunkGroups, change)
+		}
+
+		for _, pr := range group.PullRequests {
+			before := previousPRs[reportPRKey(pr)]
+			change := ReportStatusChange{
+				Group:          group.Name(),
+				Repository:     pr.Repository,
+				Number:         pr.Number,
+				PreviousStatus: before.Status,
+				Status:         pr.Status,
+				URL:            pr.URL,
+			}
+			if before.Status != "" && pr.Status != "" && before.Status != pr.Status {
+				comparison.StatusChanges = append(comparison.StatusChanges, change)
+			}
+			if !comparison.PreviousEndTime.IsZero() && IsStale(pr.CreatedAt, pr.FailingSince, staleAfter, now) &&
+				!IsStale(pr.CreatedAt, pr.FailingSince, staleAfter, comparison.PreviousEndTime) {
+				comparison.NewlyStale = append(comparison.NewlyStale, change)
+			}
+		}
+	}
+	for _, group := range previous.Groups {
+		if currentCounts[group.Name()] {
+			continue
+		}
+		change := ReportGroupChange{Group: group.Name(), PreviousCount: group.Count}
+		if count, ok := current.BelowMinimum[group.Name()]; ok {
+			change.Count = count
+			comparison.ShrunkGroups = append(comparison.ShrunkGroups, change)
+		} else {
+			comparison.RemovedGroups = append(comparison.RemovedGroups, change)
+		}
+	}
+
+	sortGroupChanges(comparison.NewGroups)
+	sortGroupChanges(comparison.GrownGroups)
+	sortGroupChanges(comparison.ShrunkGroups)
+	sortGroupChanges(comparison.RemovedGroups)
+	sortStatusChanges(comparison.StatusChanges)
+	sortStatusChanges(comparison.NewlyStale)
+	return comparison
+}
+
+// CheckComparable returns an error when previous was produced with different
+// grouping or filters than the current metadata records, since groups would
+// then appear or disappear for reasons other than changes to the PRs. Reports
+// saved without metadata cannot be checked and are accepted.
+func CheckComparable(previous *ReportResult, current *ReportMetadata) error {
+	groupBy := (&ReportResult{Metadata: current}).GroupBy()
+	if previous.GroupBy() != groupBy {
+		return fmt.Errorf("it is grouped by %s, but this report is grouped by %s", previous.GroupBy(), groupBy)
+	}
+	before := previous.Metadata
+	if before == nil {
+		return nil
+	}
+	if before.MinGroupSize != current.MinGroupSize {
+		return fmt.Errorf("it used --min-group-size %d, but this report uses %d", before.MinGroupSize, current.MinGroupSize)
+	}
+	filters := []struct {
+		flag          string
+		before, after string
+	}{
+		{"--source-branch-prefix", filterList(before.SourceBranchPrefixes), filterList(current.SourceBranchPrefixes)},
+		{"--author", before.Author, current.Author},
+		{"--label", filterList(before.Labels), filterList(current.Labels)},
+		{"--exclude-label", filterList(before.ExcludeLabels), filterList(current.ExcludeLabels)},
+		{"--min-age", before.MinAge, current.MinAge},
+		{"--max-age", before.MaxAge, current.MaxAge},
+		{"--repo", filterList(before.Repos), filterList(current.Repos)},
+		{"--repo-limit", filterLimit(before.RepoLimit), filterLimit(current.RepoLimit)},
+	}
+	for _, filter := range filters {
+		if filter.before != filter.after {
+			return fmt.Errorf("it used %s %s, but this report uses %s", filter.flag, filterValue(filter.before), filterValue(filter.after))
+		}
+	}
+	return nil
+}
+
+// filterList joins repeatable filter values in sorted order, since their
+// order does not change which PRs match.
+func filterList(values []string) string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// filterLimit formats a limit, where 0 means none.
+func filterLimit(limit int) string {
+	if limit == 0 {
+		return ""
+	}
+	return strconv.Itoa(limit)
+}
+
+// filterValue quotes a filter value for CheckComparable's error, or says
+// "none" when the filter was not set.
+func filterValue(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return strconv.Quote(value)
+}
+
+func reportPRKey(pr ReportPullRequest) string {
+	return fmt.Sprintf("%s#%d", pr.Repository, pr.Number)
+}
+
+// sortGroupChanges orders group changes by the size of the change, largest
+// first, then by group name.
+func sortGroupChanges(changes []ReportGroupChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		di, dj := abs(changes[i].Count-changes[i].PreviousCount), abs(changes[j].Count-changes[j].PreviousCount)
+		if di != dj {
+			return di > dj
+		}
+		return changes[i].Group < changes[j].Group
+	})
+}
+
+func sortStatusChanges(changes []ReportStatusChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Repository != b.Repository {
+			return a.Repository < b.Repository
+		}
+		return a.Number < b.Number
+	})
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// PrintReportComparison prints the changes since the previous report.
+func (c *Console) PrintReportComparison(comparison *ReportComparison) {
+	fmt.Fprintln(c.w)
+	title := "Changes since previous report"
+	if !comparison.PreviousEndTime.IsZero() {
+		title += " (" + comparison.PreviousEndTime.Local().Format("2006-01-02 15:04") + ")"
+	}
+	fmt.Fprintln(c.w, c.Bold(title))
+	if !comparison.HasChanges() {
+		fmt.Fprintln(c.w, c.Dim("  No changes."))
+		return
+	}
+
+	printGroups := func(label string, changes []ReportGroupChange, format func(ReportGroupChange) string) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(c.w, "  %s\n", label)
+		for _, change := range changes {
+			fmt.Fprintf(c.w, "    %s\n", format(change))
+		}
+	}
+	printGroups("New groups:", comparison.NewGroups, func(g ReportGroupChange) string {
+		return fmt.Sprintf("%s %s %s", c.Green("+"), g.Group, c.Dim(fmt.Sprintf("(%d PRs)", g.Count)))
+	})
+	printGroups("Grown groups:", comparison.GrownGroups, func(g ReportGroupChange) string {
+		return fmt.Sprintf("%s %s %s", c.Yellow("↑"), g.Group, c.Dim(fmt.Sprintf("(%d → %d PRs)", g.PreviousCount, g.Count)))
+	})
+	printGroups("Shrunk groups:", comparison.ShrunkGroups, func(g ReportGroupChange) string {
+		return fmt.Sprintf("%s %s %s", c.Green("↓"), g.Group, c.Dim(fmt.Sprintf("(%d → %d PRs)", g.PreviousCount, g.Count)))
+	})
+	printGroups("Removed groups:", comparison.RemovedGroups, func(g ReportGroupChange) string {
+		return fmt.Sprintf("%s %s %s", c.Green("-"), g.Group, c.Dim(fmt.Sprintf("(was %d PRs)", g.PreviousCount)))
+	})
+
+	printPRs := func(label string, changes []ReportStatusChange, format func(ReportStatusChange) string) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(c.w, "  %s\n", label)
+		for _, change := range changes {
+			fmt.Fprintf(c.w, "    %s #%d %s %s\n", change.Repository, change.Number, format(change), c.Dim(change.Group))
+		}
+	}
+	printPRs("Status changes:", comparison.StatusChanges, func(s ReportStatusChange) string {
+		return c.reportStatusColor(s.PreviousStatus, s.PreviousStatus) + " → " + c.reportStatusColor(s.Status, s.Status)
+	})
+	printPRs("Newly stale:", comparison.NewlyStale, func(s ReportStatusChange) string {
+		return c.reportStatusColor(s.Status, s.Status)
+	})
+}
+
+// writeReportComparisonMarkdown writes the changes since the previous report
+// as Markdown tables.
+func writeReportComparisonMarkdown(w io.Writer, comparison *ReportComparison) {
+	fmt.Fprintln(w, "\n### Changes since previous report")
+	if !comparison.PreviousEndTime.IsZero() {
+		fmt.Fprintf(w, "\nPrevious report: %s\n", comparison.PreviousEndTime.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+	if !comparison.HasChanges() {
+		fmt.Fprintln(w, "\nNo changes.")
+		return
+	}
+
+	groups := []struct {
+		label   string
+		changes []ReportGroupChange
+	}{
+		{"New", comparison.NewGroups},
+		{"Grown", comparison.GrownGroups},
+		{"Shrunk", comparison.ShrunkGroups},
+		{"Removed", comparison.RemovedGroups},
+	}
+	if len(comparison.NewGroups)+len(comparison.GrownGroups)+len(comparison.ShrunkGroups)+len(comparison.RemovedGroups) > 0 {
+		fmt.Fprintln(w, "\n| Change | Group | Before | Now |")
+		fmt.Fprintln(w, "|--------|-------|--------|-----|")
+		for _, group := range groups {
+			for _, change := range group.changes {
+				fmt.Fprintf(w, "| %s | `%s` | %d | %d |\n", group.label, markdownCell(change.Group), change.PreviousCount, change.Count)
+			}
+		}
+	}
+
+	prs := []struct {
+		label   string
+		changes []ReportStatusChange
+	}{
+		{"Status changed", comparison.StatusChanges},
+		{"Newly stale", comparison.NewlyStale},
+	}
+	if len(comparison.StatusChanges)+len(comparison.NewlyStale) > 0 {
+		fmt.Fprintln(w, "\n| Change | Repository | PR | Before | Now |")
+		fmt.Fprintln(w, "|--------|------------|----|--------|-----|")
+		for _, group := range prs {
+			for _, change := range group.changes {
+				link := markdownLink(fmt.Sprintf("#%d", change.Number), change.URL)
+				fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", group.label, markdownCell(change.Repository), link,
+					markdownCell(change.PreviousStatus), markdownCell(change.Status))
+			}
+		}
+	}
+}
+
+// reportGroupChangeSnake is ReportGroupChange with snake_case keys.
+type reportGroupChangeSnake struct {
+	Group         string `json:"group"`
+	PreviousCount int    `json:"previous_count"`
+	Count         int    `json:"count"`
+}
+
+// reportStatusChangeSnake is ReportStatusChange with snake_case keys.
+type reportStatusChangeSnake struct {
+	Group          string `json:"group"`
+	Repository     string `json:"repository"`
+	Number         int    `json:"number"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+	URL            string `json:"url,omitempty"`
+}
+
+// reportComparisonSnake is ReportComparison with snake_case keys.
+type reportComparisonSnake struct {
+	PreviousEndTime time.Time                 `json:"previous_end_time,omitzero"`
+	NewGroups       []reportGroupChangeSnake  `json:"new_groups"`
+	GrownGroups     []reportGroupChangeSnake  `json:"grown_groups"`
+	ShrunkGroups    []reportGroupChangeSnake  `json:"shrunk_groups"`
+	RemovedGroups   []reportGroupChangeSnake  `json:"removed_groups"`
+	StatusChanges   []reportStatusChangeSnake `json:"status_changes"`
+	NewlyStale      []reportStatusChangeSnake `json:"newly_stale"`
+}
+
+// snakeCase converts the comparison to its snake_case form.
+func (c *ReportComparison) snakeCase() *reportComparisonSnake {
+	groups := func(changes []ReportGroupChange) []reportGroupChangeSnake {
+		snake := make([]reportGroupChangeSnake, 0, len(changes))
+		for _, change := range changes {
+			snake = append(snake, reportGroupChangeSnake(change))
+		}
+		return snake
+	}
+	prs := func(changes []ReportStatusChange) []reportStatusChangeSnake {
+		snake := make([]reportStatusChangeSnake, 0, len(changes))
+		for _, change := range changes {
+			snake = append(snake, reportStatusChangeSnake(change))
+		}
+		return snake
+	}
+	return &reportComparisonSnake{
+		PreviousEndTime: c.PreviousEndTime,
+		NewGroups:       groups(c.NewGroups),
+		GrownGroups:     groups(c.GrownGroups),
+		ShrunkGroups:    groups(c.ShrunkGroups),
+		RemovedGroups:   groups(c.RemovedGroups),
+		StatusChanges:   prs(c.StatusChanges),
+		NewlyStale:      prs(c.NewlyStale),
+	}
+}
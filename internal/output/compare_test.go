@@ -0,0 +1,216 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func compareFixtures() (*ReportResult, *ReportResult) {
+	previous := &ReportResult{
+		Metadata: &ReportMetadata{Org: "myorg", StartTime: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), EndTime: time.Date(2026, 3, 1, 9, 5, 0, 0, time.UTC)},
+		Groups: []ReportGroup{
+			{SourceBranch: "deps/a", Count: 3, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 1, Status: "checks failing"},
+				{Repository: "web", Number: 2, Status: "conflict"},
+				{Repository: "cli", Number: 3, Status: "passing"},
+			}},
+			{SourceBranch: "deps/b", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 4, Status: "passing"},
+				{Repository: "web", Number: 5, Status: "passing"},
+			}},
+			{SourceBranch: "deps/c", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 6, Status: "passing"},
+				{Repository: "web", Number: 7, Status: "passing"},
+			}},
+		},
+	}
+	// #2 was already stale a week ago, #4 became stale since then by age and
+	// #8 by failing checks, and #5 is not stale yet.
+	current := &ReportResult{
+		Metadata: &ReportMetadata{Org: "myorg", EndTime: time.Date(2026, 3, 8, 9, 5, 0, 0, time.UTC)},
+		Groups: []ReportGroup{
+			{SourceBranch: "deps/a", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 1, Status: "passing"},
+				{Repository: "web", Number: 2, Status: "conflict", CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+			{SourceBranch: "deps/b", Count: 3, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 4, Status: "passing", CreatedAt: time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)},
+				{Repository: "web", Number: 5, Status: "passing", CreatedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+				{Repository: "cli", Number: 8, Status: "checks failing", FailingSince: time.Date(2026, 2, 21, 0, 0, 0, 0, time.UTC)},
+			}},
+			{SourceBranch: "deps/d", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 9},
+				{Repository: "web", Number: 10},
+			}},
+		},
+	}
+	return previous, current
+}
+
+func TestCompareReports(t *testing.T) {
+	previous, current := compareFixtures()
+	comparison := CompareReports(previous, current, 14*24*time.Hour)
+
+	check := func(name string, got []ReportGroupChange, want ...ReportGroupChange) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s = %+v, want %+v", name, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Errorf("%s[%d] = %+v, want %+v", name, i, got[i], want[i])
+			}
+		}
+	}
+	check("NewGroups", comparison.NewGroups, ReportGroupChange{Group: "deps/d", Count: 2})
+	check("GrownGroups", comparison.GrownGroups, ReportGroupChange{Group: "deps/b", PreviousCount: 2, Count: 3})
+	check("ShrunkGroups", comparison.ShrunkGroups, ReportGroupChange{Group: "deps/a", PreviousCount: 3, Count: 2})
+	check("RemovedGroups", comparison.RemovedGroups, ReportGroupChange{Group: "deps/c", PreviousCount: 2})
+
+	if len(comparison.StatusChanges) != 1 || comparison.StatusChanges[0].Number != 1 ||
+		comparison.StatusChanges[0].PreviousStatus != "checks failing" || comparison.StatusChanges[0].Status != "passing" {
+		t.Errorf("StatusChanges = %+v, want api #1 checks failing → passing", comparison.StatusChanges)
+	}
+	if len(comparison.NewlyStale) != 2 || comparison.NewlyStale[0].Number != 4 || comparison.NewlyStale[0].PreviousStatus != "passing" ||
+		comparison.NewlyStale[1].Number != 8 || comparison.NewlyStale[1].PreviousStatus != "" {
+		t.Errorf("NewlyStale = %+v, want api #4 and cli #8", comparison.NewlyStale)
+	}
+	if !comparison.PreviousEndTime.Equal(previous.Metadata.EndTime) {
+		t.Errorf("PreviousEndTime = %v", comparison.PreviousEndTime)
+	}
+
+	if CompareReports(current, current, 14*24*time.Hour).HasChanges() {
+		t.Error("comparing a report with itself reported changes")
+	}
+
+	// Without the previous end time, nothing is known to be newly stale.
+	previous.Metadata = nil
+	if got := CompareReports(previous, current, 14*24*time.Hour).NewlyStale; len(got) != 0 {
+		t.Errorf("NewlyStale = %+v, want none without the previous end time", got)
+	}
+}
+
+func TestCompareReportsGroupBelowMinimum(t *testing.T) {
+	previous, current := compareFixtures()
+	current.BelowMinimum = map[string]int{"deps/c": 1}
+	comparison := CompareReports(previous, current, 14*24*time.Hour)
+
+	if len(comparison.RemovedGroups) != 0 {
+		t.Errorf("RemovedGroups = %+v, want none", comparison.RemovedGroups)
+	}
+	want := ReportGroupChange{Group: "deps/c", PreviousCount: 2, Count: 1}
+	found := false
+	for _, change := range comparison.ShrunkGroups {
+		found = found || change == want
+	}
+	if !found {
+		t.Errorf("ShrunkGroups = %+v, want %+v", comparison.ShrunkGroups, want)
+	}
+}
+
+func TestCheckComparable(t *testing.T) {
+	previous := &ReportResult{Metadata: &ReportMetadata{
+		MinGroupSize:         2,
+		SourceBranchPrefixes: []string{"renovate/", "dependabot/"},
+		Author:               "dependabot[bot]",
+		Labels:               []string{"deps", "auto"},
+		MinAge:               "7d",
+		Repos:                []string{"web", "api"},
+	}}
+	current := func() *ReportMetadata {
+		return &ReportMetadata{
+			MinGroupSize:         2,
+			SourceBranchPrefixes: []string{"dependabot/", "renovate/"},
+			Author:               "dependabot[bot]",
+			Labels:               []string{"auto", "deps"},
+			MinAge:               "7d",
+			Repos:                []string{"api", "web"},
+		}
+	}
+
+	if err := CheckComparable(previous, current()); err != nil {
+		t.Errorf("CheckComparable() error = %v, want repeated filters compared in any order", err)
+	}
+	if err := CheckComparable(&ReportResult{}, &ReportMetadata{MinGroupSize: 5}); err != nil {
+		t.Errorf("CheckComparable() error = %v, want reports without metadata accepted", err)
+	}
+
+	tests := []struct {
+		flag   string
+		change func(*ReportMetadata)
+	}{
+		{"grouped by", func(m *ReportMetadata) { m.GroupBy = "package" }},
+		{"--min-group-size", func(m *ReportMetadata) { m.MinGroupSize = 3 }},
+		{"--source-branch-prefix", func(m *ReportMetadata) { m.SourceBranchPrefixes = []string{"dependabot/"} }},
+		{"--author", func(m *ReportMetadata) { m.Author = "" }},
+		{"--label", func(m *ReportMetadata) { m.Labels = []string{"deps"} }},
+		{"--exclude-label", func(m *ReportMetadata) { m.ExcludeLabels = []string{"wip"} }},
+		{"--min-age", func(m *ReportMetadata) { m.MinAge = "1d" }},
+		{"--max-age", func(m *ReportMetadata) { m.MaxAge = "30d" }},
+		{"--repo", func(m *ReportMetadata) { m.Repos = nil }},
+		{"--repo-limit", func(m *ReportMetadata) { m.RepoLimit = 10 }},
+	}
+	for _, tt := range tests {
+		metadata := current()
+		tt.change(metadata)
+		if err := CheckComparable(previous, metadata); err == nil || !strings.Contains(err.Error(), tt.flag) {
+			t.Errorf("CheckComparable() error = %v, want %s mismatch", err, tt.flag)
+		}
+	}
+}
+
+func TestReadReportCasings(t *testing.T) {
+	previous, _ := compareFixtures()
+	for _, casing := range []JSONCasing{CasingCamel, CasingSnake} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, true, false)
+		w.SetJSONCasing(casing)
+		if err := w.WriteReportResult(previous, "standard"); err != nil {
+			t.Fatalf("WriteReportResult() error = %v", err)
+		}
+		parsed, err := parseReport(buf.Bytes())
+		if err != nil {
+			t.Fatalf("parseReport(%s) error = %v", casing, err)
+		}
+		if len(parsed.Groups) != 3 || parsed.Groups[0].SourceBranch != "deps/a" || parsed.Groups[0].PullRequests[0].Status != "checks failing" {
+			t.Errorf("parseReport(%s) groups = %+v", casing, parsed.Groups)
+		}
+		if parsed.Metadata == nil || !parsed.Metadata.EndTime.Equal(previous.Metadata.EndTime) {
+			t.Errorf("parseReport(%s) metadata = %+v", casing, parsed.Metadata)
+		}
+	}
+
+	if _, err := parseReport([]byte(`{"schema_version": 99, "groups": []}`)); err == nil {
+		t.Error("parseReport() accepted a newer schema_version")
+	}
+	if _, err := parseReport([]byte(`not json`)); err == nil {
+		t.Error("parseReport() accepted invalid JSON")
+	}
+}
+
+func TestWriteReportComparison(t *testing.T) {
+	previous, current := compareFixtures()
+	current.Comparison = CompareReports(previous, current, 14*24*time.Hour)
+
+	var buf bytes.Buffer
+	if err := NewWriter(&buf, false, true).WriteReportResult(current, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+	for _, want := range []string{"Changes since previous report", "+ deps/d", "deps/b (2 → 3 PRs)", "deps/c (was 2 PRs)", "api #1 checks failing → passing", "Newly stale:", "api #4 passing"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("text output missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	if err := NewFormatWriter(&buf, FormatMarkdown, true).WriteReportResult(current, "brief"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+	for _, want := range []string{"### Changes since previous report", "| Removed | `deps/c` | 2 | 0 |", "| Status changed | api | #1 | checks failing | passing |", "| Newly stale | api | #4 | passing | passing |"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("markdown output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
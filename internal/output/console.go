@@ -258,6 +258,17 @@ func (c *Console) PrintPendingAction(repo RepositoryResult, pr PullRequestResult
 	return 2
 }
 
+// PrintNumberedPendingAction prints a pending action prefixed with its
+// selection number and returns the number of lines written.
+func (c *Console) PrintNumberedPendingAction(n int, repo RepositoryResult, pr PullRequestResult) int {
+	symbol := c.getActionSymbol(pr.Action)
+	colored := c.colorAction(symbol, pr.Action)
+	title := truncateString(pr.Title, 50)
+	fmt.Fprintf(c.w, "  %s %s %s #%d %s\n", c.Dim(fmt.Sprintf("%3d", n)), colored, repo.FullName, pr.Number, title)
+	fmt.Fprintf(c.w, "        %s\n", c.colorActionText(string(pr.Action), pr.Action))
+	return 2
+}
+
 // PrintSummary prints a condensed summary line.
 func (c *Console) PrintSummary(summary RunSummary) {
 	fmt.Fprintf(c.w, "%s\n", c.Dim("────────────────────────────────────────────────────"))
@@ -282,9 +293,21 @@ func (c *Console) PrintSummary(summary RunSummary) {
 	if summary.WouldRebase > 0 {
 		parts = append(parts, c.Yellow(fmt.Sprintf("%d would rebase", summary.WouldRebase)))
 	}
+	if summary.RecreateRequested > 0 {
+		parts = append(parts, c.Yellow(fmt.Sprintf("%d recreate requested", summary.RecreateRequested)))
+	}
+	if summary.IgnoredSuccess > 0 {
+		parts = append(parts, c.Yellow(fmt.Sprintf("%d ignored", summary.IgnoredSuccess)))
+	}
 	if summary.WouldClose > 0 {
 		parts = append(parts, c.Yellow(fmt.Sprintf("%d would close", summary.WouldClose)))
 	}
+	if summary.WouldRecreate > 0 {
+		parts = append(parts, c.Yellow(fmt.Sprintf("%d would recreate", summary.WouldRecreate)))
+	}
+	if summary.WouldIgnore > 0 {
+		parts = append(parts, c.Yellow(fmt.Sprintf("%d would ignore", summary.WouldIgnore)))
+	}
 	if summary.ReadyToMerge > 0 {
 		parts = append(parts, c.Green(fmt.Sprintf("%d ready to merge", summary.ReadyToMerge)))
 	}
@@ -297,6 +320,12 @@ func (c *Console) PrintSummary(summary RunSummary) {
 	if summary.CloseFailed > 0 {
 		parts = append(parts, c.Red(fmt.Sprintf("%d close failed", summary.CloseFailed)))
 	}
+	if summary.RecreateFailed > 0 {
+		parts = append(parts, c.Red(fmt.Sprintf("%d recreate failed", summary.RecreateFailed)))
+	}
+	if summary.IgnoreFailed > 0 {
+		parts = append(parts, c.Red(fmt.Sprintf("%d ignore failed", summary.IgnoreFailed)))
+	}
 	if summary.Skipped > 0 {
 		parts = append(parts, c.Dim(fmt.Sprintf("%d skipped", summary.Skipped)))
 	}
@@ -304,14 +333,21 @@ func (c *Console) PrintSummary(summary RunSummary) {
 	fmt.Fprintf(c.w, "%s\n", strings.Join(parts, " │ "))
 }
 
+// StyledAction returns the colored symbol and text for an action, as shown in
+// repository results.
+func (c *Console) StyledAction(action Action) (string, string) {
+	return c.colorAction(c.getActionSymbol(action), action), c.colorActionText(string(action), action)
+}
+
 // getActionSymbol returns a Unicode symbol for the action type.
 func (c *Console) getActionSymbol(action Action) string {
 	switch action {
 	case ActionMerged, ActionWouldMerge, ActionReadyMerge:
 		return "✓"
-	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose:
+	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose,
+		ActionRecreateRequested, ActionWouldRecreate, ActionIgnored, ActionWouldIgnore:
 		return "↻"
-	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed:
+	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed, ActionRecreateFailed, ActionIgnoreFailed:
 		return "✗"
 	default:
 		if strings.HasPrefix(string(action), "skip:") {
@@ -326,9 +362,10 @@ func (c *Console) colorAction(symbol string, action Action) string {
 	switch action {
 	case ActionMerged, ActionWouldMerge, ActionReadyMerge:
 		return c.Green(symbol)
-	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose:
+	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose,
+		ActionRecreateRequested, ActionWouldRecreate, ActionIgnored, ActionWouldIgnore:
 		return c.Yellow(symbol)
-	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed:
+	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed, ActionRecreateFailed, ActionIgnoreFailed:
 		return c.Red(symbol)
 	default:
 		if strings.HasPrefix(string(action), "skip:") {
@@ -343,9 +380,10 @@ func (c *Console) colorActionText(text string, action Action) string {
 	switch action {
 	case ActionMerged, ActionWouldMerge, ActionReadyMerge:
 		return c.Green(text)
-	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose:
+	case ActionRebased, ActionWouldRebase, ActionClosed, ActionWouldClose,
+		ActionRecreateRequested, ActionWouldRecreate, ActionIgnored, ActionWouldIgnore:
 		return c.Yellow(text)
-	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed:
+	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed, ActionRecreateFailed, ActionIgnoreFailed:
 		return c.Red(text)
 	default:
 		return c.Dim(text)
@@ -0,0 +1,91 @@
+package output
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+)
+
+// runColumns is the column order for delimited run results.
+var runColumns = []string{"repository", "number", "head_branch", "action", "skip_reason", "reason", "url"}
+
+// reportColumns is the column order for delimited report results.
+var reportColumns = []string{"source_branch", "repository", "number", "status", "title"}
+
+// delimiter returns the field separator for a delimited format.
+func (w *Writer) delimiter() rune {
+	if w.format == FormatTSV {
+		return '\t'
+	}
+	return ','
+}
+
+// writeDelimited writes a header row followed by one row per pull request,
+// quoting fields that contain the delimiter, quotes, or line breaks. Cells
+// that a spreadsheet would run as a formula are escaped.
+func (w *Writer) writeDelimited(header []string, rows [][]string) error {
+	cw := csv.NewWriter(w.out)
+	cw.Comma = w.delimiter()
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = escapeFormula(cell)
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// writeRunDelimited writes the run result as CSV or TSV, one row per PR.
+func (w *Writer) writeRunDelimited(result *RunResult) error {
+	var rows [][]string
+	for _, repo := range result.Repositories {
+		for _, pr := range repo.PullRequests {
+			rows = append(rows, []string{
+				repo.FullName,
+				strconv.Itoa(pr.Number),
+				pr.HeadBranch,
+				string(pr.Action),
+				string(pr.SkipReason),
+				pr.Reason,
+				pr.URL,
+			})
+		}
+	}
+	return w.writeDelimited(runColumns, rows)
+}
+
+// writeReportDelimited writes the report as CSV or TSV, one row per PR.
+func (w *Writer) writeReportDelimited(result *ReportResult) error {
+	var rows [][]string
+	for _, group := range result.Groups {
+		for _, pr := range group.PullRequests {
+			rows = append(rows, []string{
+				group.Name(),
+				pr.Repository,
+				strconv.Itoa(pr.Number),
+				pr.Status,
+				pr.Title,
+			})
+		}
+	}
+	columns := reportColumns
+	if result.GroupBy() != GroupByBranch || result.View() == ViewStale {
+		columns = append([]string{"group"}, reportColumns[1:]...)
+	}
+	return w.writeDelimited(columns, rows)
+}
+
+// escapeFormula prefixes a cell with a single quote when it starts with a
+// character that makes spreadsheets treat it as a formula, such as a PR title
+// beginning with "=".
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
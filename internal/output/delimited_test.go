@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteResultCSV(t *testing.T) {
+	result := &RunResult{
+		Repositories: []RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []PullRequestResult{
+				{Number: 12, HeadBranch: "dependabot/npm/foo", URL: "https://github.com/myorg/api/pull/12", Action: ActionMerged, Reason: "successfully merged"},
+				{Number: 13, HeadBranch: "dependabot/npm/bar", URL: "https://github.com/myorg/api/pull/13", Action: ActionSkipChecksFailing, SkipReason: ReasonChecksFailing, Reason: "build, \"lint\" failing"},
+			}},
+			{Name: "late", FullName: "myorg/late", Skipped: true, SkipReason: "repo limit reached"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatCSV, false).WriteResult(result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+
+	want := "repository,number,head_branch,action,skip_reason,reason,url\n" +
+		"myorg/api,12,dependabot/npm/foo,merged,,successfully merged,https://github.com/myorg/api/pull/12\n" +
+		"myorg/api,13,dependabot/npm/bar,skip: checks failing,checks failing,\"build, \"\"lint\"\" failing\",https://github.com/myorg/api/pull/13\n"
+	if buf.String() != want {
+		t.Errorf("CSV output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteReportResultTSV(t *testing.T) {
+	result := &ReportResult{
+		Groups: []ReportGroup{
+			{SourceBranch: "dependabot/npm/foo-1.2.3", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "repo-a", Number: 1, Status: "passing", Title: "Bump foo"},
+				{Repository: "repo-b", Number: 2, Status: "needs-rebase", Title: "Bump\tfoo"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatTSV, false).WriteReportResult(result, "brief"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+
+	want := "source_branch\trepository\tnumber\tstatus\ttitle\n" +
+		"dependabot/npm/foo-1.2.3\trepo-a\t1\tpassing\tBump foo\n" +
+		"dependabot/npm/foo-1.2.3\trepo-b\t2\tneeds-rebase\t\"Bump\tfoo\"\n"
+	if buf.String() != want {
+		t.Errorf("TSV output =\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestWriteDelimitedEmptyHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatCSV, false).WriteReportResult(&ReportResult{}, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+	if got, want := buf.String(), "source_branch,repository,number,status,title\n"; got != want {
+		t.Errorf("empty CSV report = %q, want %q", got, want)
+	}
+}
+
+func TestWriteDelimitedEscapesFormulas(t *testing.T) {
+	result := &ReportResult{
+		Groups: []ReportGroup{
+			{SourceBranch: "feature/x", Count: 4, PullRequests: []ReportPullRequest{
+				{Repository: "repo-a", Number: 1, Status: "passing", Title: "=HYPERLINK(\"https://example.com\")"},
+				{Repository: "repo-a", Number: 2, Status: "passing", Title: "+1 to the build"},
+				{Repository: "repo-a", Number: 3, Status: "passing", Title: "@mention fix"},
+				{Repository: "repo-a", Number: 4, Status: "passing", Title: "-x flag"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatCSV, false).WriteReportResult(result, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+
+	want := "source_branch,repository,number,status,title\n" +
+		"feature/x,repo-a,1,passing,\"'=HYPERLINK(\"\"https://example.com\"\")\"\n" +
+		"feature/x,repo-a,2,passing,'+1 to the build\n" +
+		"feature/x,repo-a,3,passing,'@mention fix\n" +
+		"feature/x,repo-a,4,passing,'-x flag\n"
+	if buf.String() != want {
+		t.Errorf("CSV output =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,94 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventType identifies an NDJSON event.
+type EventType string
+
+const (
+	EventRunStarted  EventType = "run_started"
+	EventRepository  EventType = "repository"
+	EventPullRequest EventType = "pull_request"
+	EventAction      EventType = "action"
+	EventSummary     EventType = "summary"
+)
+
+// Event is one line of NDJSON output. Only the fields relevant to the event
+// type are set.
+type Event struct {
+	SchemaVersion int                `json:"schema_version"`
+	Type          EventType          `json:"type"`
+	Time          time.Time          `json:"time"`
+	Metadata      *RunMetadata       `json:"metadata,omitempty"`
+	Repository    string             `json:"repository,omitempty"`
+	Candidates    *int               `json:"candidates,omitempty"`
+	Skipped       bool               `json:"skipped,omitempty"`
+	SkipReason    string             `json:"skip_reason,omitempty"`
+	PullRequest   *PullRequestResult `json:"pull_request,omitempty"`
+	Summary       *RunSummary        `json:"summary,omitempty"`
+}
+
+// EventStream writes events as newline-delimited JSON as they happen, so a
+// wrapper can react during the run and a partial run still leaves a record.
+// A nil *EventStream discards all events.
+type EventStream struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewEventStream creates an EventStream writing to w.
+func NewEventStream(w io.Writer) *EventStream {
+	return &EventStream{encoder: json.NewEncoder(w)}
+}
+
+// emit writes a single event line. Write errors are ignored like console
+// output; the final result is still written at the end of the run.
+func (s *EventStream) emit(event Event) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	event.SchemaVersion = SchemaVersion
+	event.Time = time.Now().UTC()
+	_ = s.encoder.Encode(event)
+}
+
+// RunStarted records the start of a run.
+func (s *EventStream) RunStarted(metadata RunMetadata) {
+	s.emit(Event{Type: EventRunStarted, Metadata: &metadata})
+}
+
+// RepositoryScanned records a repository after its pull requests were evaluated.
+func (s *EventStream) RepositoryScanned(repo RepositoryResult) {
+	candidates := len(repo.PullRequests)
+	s.emit(Event{
+		Type:       EventRepository,
+		Repository: repo.FullName,
+		Candidates: &candidates,
+		Skipped:    repo.Skipped,
+		SkipReason: repo.SkipReason,
+	})
+}
+
+// PullRequestEvaluated records the decision for a pull request.
+func (s *EventStream) PullRequestEvaluated(repo string, pr PullRequestResult) {
+	s.emit(Event{Type: EventPullRequest, Repository: repo, PullRequest: &pr})
+}
+
+// ActionExecuted records the outcome of an executed action.
+func (s *EventStream) ActionExecuted(repo string, pr PullRequestResult) {
+	s.emit(Event{Type: EventAction, Repository: repo, PullRequest: &pr})
+}
+
+// RunFinished records the final summary.
+func (s *EventStream) RunFinished(result *RunResult) {
+	metadata := result.Metadata
+	summary := result.Summary
+	s.emit(Event{Type: EventSummary, Metadata: &metadata, Summary: &summary})
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNilEventStreamDiscardsEvents(t *testing.T) {
+	var s *EventStream
+	s.RunStarted(RunMetadata{})
+	s.PullRequestEvaluated("org/repo", PullRequestResult{})
+	s.RunFinished(&RunResult{})
+}
+
+func TestWriteResultNDJSONWritesSummaryEvent(t *testing.T) {
+	result := &RunResult{
+		Metadata: RunMetadata{Org: "myorg", Mode: "merge mode"},
+		Summary:  RunSummary{ReposProcessed: 3, MergedSuccess: 2},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatNDJSON, false).WriteResult(result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a single summary line, got %d:\n%s", len(lines), buf.String())
+	}
+	var event Event
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if event.Type != EventSummary || event.Summary == nil || event.Summary.MergedSuccess != 2 || event.Metadata == nil || event.Metadata.Org != "myorg" {
+		t.Errorf("summary event = %+v", event)
+	}
+	if event.Time.IsZero() {
+		t.Error("summary event has no time")
+	}
+}
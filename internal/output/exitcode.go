@@ -0,0 +1,96 @@
+package output
+
+import "strings"
+
+// Process exit codes. 1 is reserved for fatal errors such as invalid flags or
+// a failed repository listing.
+const (
+	ExitSuccess      = 0
+	ExitError        = 1
+	ExitActionFailed = 2
+	ExitAPIError     = 3
+	ExitNothingToDo  = 4
+	ExitInterrupted  = 130 // 128 + SIGINT, as shells report an interrupted command
+)
+
+// FailCondition is a run outcome that can make the process exit non-zero.
+type FailCondition string
+
+const (
+	FailActionFailed FailCondition = "action-failed"
+	FailMergeFailed  FailCondition = "merge-failed"
+	FailRebaseFailed FailCondition = "rebase-failed"
+	FailCloseFailed  FailCondition = "close-failed"
+	FailAPIError     FailCondition = "api-error"
+	FailNothingToDo  FailCondition = "nothing-to-do"
+	FailOnNone       FailCondition = "none"
+)
+
+// FailConditions lists the values accepted by --fail-on.
+var FailConditions = []FailCondition{
+	FailActionFailed, FailMergeFailed, FailRebaseFailed, FailCloseFailed, FailAPIError, FailNothingToDo, FailOnNone,
+}
+
+// FailConditionList returns the accepted --fail-on values for error messages.
+func FailConditionList() string {
+	names := make([]string, len(FailConditions))
+	for i, condition := range FailConditions {
+		names[i] = string(condition)
+	}
+	return strings.Join(names, ", ")
+}
+
+// DefaultFailOn is used when --fail-on is not given.
+var DefaultFailOn = []FailCondition{FailActionFailed, FailAPIError}
+
+// ExitCode derives the process exit code from a run result. An interrupted run
+// always exits ExitInterrupted. Otherwise only the given conditions are
+// considered; when several hold, failed actions take precedence over API
+// errors, and API errors over having nothing to do.
+func ExitCode(result *RunResult, failOn []FailCondition) int {
+	if result.Metadata.Interrupted {
+		return ExitInterrupted
+	}
+	enabled := make(map[FailCondition]bool, len(failOn))
+	for _, condition := range failOn {
+		enabled[condition] = true
+	}
+	s := result.Summary
+
+	failed := (enabled[FailActionFailed] && (s.MergeFailed > 0 || s.RebaseFailed > 0 || s.CloseFailed > 0 || s.RecreateFailed > 0 || s.IgnoreFailed > 0)) ||
+		(enabled[FailMergeFailed] && s.MergeFailed > 0) ||
+		(enabled[FailRebaseFailed] && s.RebaseFailed > 0) ||
+		(enabled[FailCloseFailed] && (s.CloseFailed > 0 || s.IgnoreFailed > 0))
+	if failed {
+		return ExitActionFailed
+	}
+	if enabled[FailAPIError] && hasAPIErrors(result) {
+		return ExitAPIError
+	}
+	if enabled[FailNothingToDo] && !hasWork(s) {
+		return ExitNothingToDo
+	}
+	return ExitSuccess
+}
+
+// hasAPIErrors reports whether a repository or PR was skipped because of a
+// GitHub API error, leaving the scan incomplete.
+func hasAPIErrors(result *RunResult) bool {
+	if result.Summary.SkippedByReason[string(ReasonAPIError)] > 0 {
+		return true
+	}
+	for _, repo := range result.Repositories {
+		if repo.Skipped && strings.HasPrefix(repo.SkipReason, "API error") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWork reports whether the run executed, planned, or found a ready action.
+func hasWork(s RunSummary) bool {
+	return s.HasPendingActions() || s.ReadyToMerge > 0 ||
+		s.MergedSuccess > 0 || s.MergeFailed > 0 || s.RebasedSuccess > 0 || s.RebaseFailed > 0 ||
+		s.ClosedSuccess > 0 || s.CloseFailed > 0 || s.RecreateRequested > 0 || s.RecreateFailed > 0 ||
+		s.IgnoredSuccess > 0 || s.IgnoreFailed > 0
+}
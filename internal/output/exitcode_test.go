@@ -0,0 +1,42 @@
+package output
+
+import "testing"
+
+func TestExitCode(t *testing.T) {
+	apiErrorRepo := []RepositoryResult{{FullName: "org/a", Skipped: true, SkipReason: "API error: 502 Bad Gateway"}}
+	repoLimit := []RepositoryResult{{FullName: "org/b", Skipped: true, SkipReason: "repo limit reached"}}
+
+	tests := []struct {
+		name   string
+		result RunResult
+		failOn []FailCondition
+		want   int
+	}{
+		{"merged", RunResult{Summary: RunSummary{MergedSuccess: 2}}, DefaultFailOn, ExitSuccess},
+		{"merge failed", RunResult{Summary: RunSummary{MergedSuccess: 1, MergeFailed: 1}}, DefaultFailOn, ExitActionFailed},
+		{"recreate failed", RunResult{Summary: RunSummary{RecreateFailed: 1}}, DefaultFailOn, ExitActionFailed},
+		{"PR API error", RunResult{Summary: RunSummary{SkippedByReason: map[string]int{"API error": 1}}}, DefaultFailOn, ExitAPIError},
+		{"repo API error", RunResult{Repositories: apiErrorRepo}, DefaultFailOn, ExitAPIError},
+		{"repo limit is not an API error", RunResult{Repositories: repoLimit, Summary: RunSummary{MergedSuccess: 1}}, DefaultFailOn, ExitSuccess},
+		{"failure wins over API error", RunResult{Repositories: apiErrorRepo, Summary: RunSummary{CloseFailed: 1}}, DefaultFailOn, ExitActionFailed},
+		{"nothing to do is opt-in", RunResult{Summary: RunSummary{Skipped: 3}}, DefaultFailOn, ExitSuccess},
+		{"nothing to do", RunResult{Summary: RunSummary{Skipped: 3}}, []FailCondition{FailNothingToDo}, ExitNothingToDo},
+		{"pending action is work", RunResult{Summary: RunSummary{WouldMerge: 1}}, []FailCondition{FailNothingToDo}, ExitSuccess},
+		{"ready to merge is work", RunResult{Summary: RunSummary{ReadyToMerge: 1}}, []FailCondition{FailNothingToDo}, ExitSuccess},
+		{"merge-failed ignores rebase failures", RunResult{Summary: RunSummary{RebaseFailed: 1}}, []FailCondition{FailMergeFailed}, ExitSuccess},
+		{"rebase-failed", RunResult{Summary: RunSummary{RebaseFailed: 1}}, []FailCondition{FailRebaseFailed}, ExitActionFailed},
+		{"close-failed covers ignore", RunResult{Summary: RunSummary{IgnoreFailed: 1}}, []FailCondition{FailCloseFailed}, ExitActionFailed},
+		{"API error not selected", RunResult{Repositories: apiErrorRepo}, []FailCondition{FailMergeFailed}, ExitSuccess},
+		{"none", RunResult{Summary: RunSummary{MergeFailed: 1}}, nil, ExitSuccess},
+		{"interrupted", RunResult{Metadata: RunMetadata{Interrupted: true}, Summary: RunSummary{MergeFailed: 1}}, DefaultFailOn, ExitInterrupted},
+		{"interrupted ignores none", RunResult{Metadata: RunMetadata{Interrupted: true}}, nil, ExitInterrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(&tt.result, tt.failOn); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
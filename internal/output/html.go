@@ -0,0 +1,225 @@
+package output
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// htmlStatusCount is the number of PRs in a group with a given status.
+type htmlStatusCount struct {
+	Status string
+	Count  int
+}
+
+// htmlGroup is a report group prepared for the HTML template.
+type htmlGroup struct {
+	ReportGroup
+	Statuses []htmlStatusCount
+}
+
+// htmlReport is the data passed to the HTML template.
+type htmlReport struct {
+	Metadata  *ReportMetadata
+	Generated string
+	Duration  string
+	Groups    []htmlGroup
+	Statuses  []string
+	TotalPRs  int
+}
+
+// writeReportHTML writes the report as a single self-contained HTML page.
+// Styles and scripts are inlined so the file can be attached to a ticket.
+func (w *Writer) writeReportHTML(result *ReportResult) error {
+	data := htmlReport{Metadata: result.Metadata}
+	if data.Metadata != nil && !data.Metadata.EndTime.IsZero() {
+		data.Generated = data.Metadata.EndTime.UTC().Format(time.RFC1123)
+		data.Duration = data.Metadata.EndTime.Sub(data.Metadata.StartTime).Round(time.Second).String()
+	}
+
+	seen := make(map[string]bool)
+	for _, group := range result.Groups {
+		counts := make(map[string]int)
+		for _, pr := range group.PullRequests {
+			counts[pr.Status]++
+			if !seen[pr.Status] {
+				seen[pr.Status] = true
+				data.Statuses = append(data.Statuses, pr.Status)
+			}
+		}
+		hg := htmlGroup{ReportGroup: group}
+		for status, n := range counts {
+			hg.Statuses = append(hg.Statuses, htmlStatusCount{Status: status, Count: n})
+		}
+		sort.Slice(hg.Statuses, func(i, j int) bool {
+			return hg.Statuses[i].Status < hg.Statuses[j].Status
+		})
+		data.Groups = append(data.Groups, hg)
+		data.TotalPRs += group.Count
+	}
+	sort.Strings(data.Statuses)
+
+	return htmlReportTemplate.Execute(w.out, data)
+}
+
+// htmlStatusClass returns the badge class for a report status. It follows the
+// colors used by reportStatusColor in the terminal output.
+func htmlStatusClass(status string) string {
+	switch status {
+	case "passing", "ready to merge", "no checks configured":
+		return "ok"
+	case "needs-rebase":
+		return "warn"
+	case "conflict", "checks failing":
+		return "fail"
+	default:
+		return "muted"
+	}
+}
+
+// htmlStatusSymbol returns the same symbol the terminal output uses for a status.
+func htmlStatusSymbol(status string) string {
+	return NewConsole(io.Discard, true, false, true).reportStatusSymbol(status)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"statusClass":  htmlStatusClass,
+	"statusSymbol": htmlStatusSymbol,
+	"join":         strings.Join,
+}).Parse(htmlReportSource))
+
+const htmlReportSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>ghprmerge report{{with .Metadata}} - {{.Org}}{{end}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1 { font-size: 1.5rem; margin-bottom: 0.25rem; }
+dl.meta { display: grid; grid-template-columns: max-content auto; gap: 0.25rem 1rem; margin: 1rem 0; }
+dl.meta dt { font-weight: 600; }
+dl.meta dd { margin: 0; }
+.controls { display: flex; gap: 0.5rem; margin: 1rem 0; }
+.controls input, .controls select { padding: 0.35rem 0.5rem; font-size: 0.9rem; }
+.controls input { flex: 1; max-width: 30rem; }
+details { border: 1px solid #d0d7de; border-radius: 6px; margin: 0.75rem 0; }
+summary { cursor: pointer; padding: 0.6rem 0.8rem; background: #f6f8fa; font-weight: 600; }
+summary code { font-size: 0.95rem; }
+summary .count { color: #656d76; font-weight: normal; margin-left: 0.5rem; }
+summary .badge { margin-left: 0.25rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4rem 0.8rem; border-top: 1px solid #d0d7de; }
+th { cursor: pointer; user-select: none; white-space: nowrap; }
+th[data-dir="asc"]::after { content: " ▲"; }
+th[data-dir="desc"]::after { content: " ▼"; }
+.badge { display: inline-block; padding: 0.1rem 0.5rem; border-radius: 1rem; font-size: 0.8rem; white-space: nowrap; }
+.badge.ok { background: #dafbe1; color: #1a7f37; }
+.badge.warn { background: #fff8c5; color: #9a6700; }
+.badge.fail { background: #ffebe9; color: #cf222e; }
+.badge.muted { background: #eaeef2; color: #656d76; }
+.empty { color: #656d76; }
+</style>
+</head>
+<body>
+<h1>ghprmerge report</h1>
+<dl class="meta">
+{{- with .Metadata}}
+<dt>Organization</dt><dd>{{.Org}}</dd>
+{{- if .SourceBranchPrefixes}}
+<dt>Source branch prefixes</dt><dd>{{join .SourceBranchPrefixes ", "}}</dd>
+{{- end}}
+<dt>Minimum group size</dt><dd>{{.MinGroupSize}}</dd>
+<dt>Repositories scanned</dt><dd>{{.ReposScanned}}</dd>
+{{- end}}
+{{- if .Generated}}
+<dt>Generated</dt><dd>{{.Generated}} ({{.Duration}})</dd>
+{{- end}}
+<dt>Totals</dt><dd>{{len .Groups}} branch groups, {{.TotalPRs}} PRs</dd>
+</dl>
+{{- if .Groups}}
+<div class="controls">
+<input id="filter" type="search" placeholder="Filter by branch, repository, or title">
+<select id="status">
+<option value="">All statuses</option>
+{{- range .Statuses}}
+<option value="{{.}}">{{statusSymbol .}} {{.}}</option>
+{{- end}}
+</select>
+</div>
+{{- range .Groups}}
+<details class="group" open>
+<summary><code>{{.Name}}</code><span class="count">{{.Count}} PRs</span>
+{{- range .Statuses}} <span class="badge {{statusClass .Status}}">{{statusSymbol .Status}} {{.Count}} {{.Status}}</span>{{end}}</summary>
+<table>
+<thead><tr><th>Repository</th><th data-type="number">PR</th><th>Title</th><th>Status</th></tr></thead>
+<tbody>
+{{- range .PullRequests}}
+<tr data-status="{{.Status}}">
+<td>{{.Repository}}</td>
+<td data-value="{{.Number}}">{{if .URL}}<a href="{{.URL}}">#{{.Number}}</a>{{else}}#{{.Number}}{{end}}</td>
+<td>{{.Title}}</td>
+<td data-value="{{.Status}}"><span class="badge {{statusClass .Status}}">{{statusSymbol .Status}} {{.Status}}</span></td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</details>
+{{- end}}
+<p id="no-match" class="empty" hidden>No pull requests match the filter.</p>
+{{- else}}
+<p class="empty">No grouped source branches found.</p>
+{{- end}}
+<script>
+(function () {
+  var filter = document.getElementById("filter");
+  var status = document.getElementById("status");
+  if (!filter) { return; }
+
+  function apply() {
+    var text = filter.value.toLowerCase();
+    var wanted = status.value;
+    var any = false;
+    document.querySelectorAll("details.group").forEach(function (group) {
+      var branch = group.querySelector("summary code").textContent.toLowerCase();
+      var shown = 0;
+      group.querySelectorAll("tbody tr").forEach(function (row) {
+        var match = (!wanted || row.dataset.status === wanted) &&
+          (!text || branch.indexOf(text) >= 0 || row.textContent.toLowerCase().indexOf(text) >= 0);
+        row.hidden = !match;
+        if (match) { shown++; }
+      });
+      group.hidden = shown === 0;
+      if (shown > 0) { any = true; }
+    });
+    document.getElementById("no-match").hidden = any;
+  }
+  filter.addEventListener("input", apply);
+  status.addEventListener("change", apply);
+
+  document.querySelectorAll("th").forEach(function (th) {
+    th.addEventListener("click", function () {
+      var table = th.closest("table");
+      var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+      var dir = th.dataset.dir === "asc" ? "desc" : "asc";
+      table.querySelectorAll("th").forEach(function (other) { delete other.dataset.dir; });
+      th.dataset.dir = dir;
+      var numeric = th.dataset.type === "number";
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[index].dataset.value || a.cells[index].textContent;
+        var y = b.cells[index].dataset.value || b.cells[index].textContent;
+        var cmp = numeric ? Number(x) - Number(y) : x.localeCompare(y);
+        return dir === "asc" ? cmp : -cmp;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
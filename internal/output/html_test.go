@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteReportResultHTML(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	result := &ReportResult{
+		Metadata: &ReportMetadata{
+			Org:                  "myorg",
+			SourceBranchPrefixes: []string{"dependabot/", "renovate/"},
+			MinGroupSize:         2,
+			ReposScanned:         12,
+			StartTime:            start,
+			EndTime:              start.Add(42 * time.Second),
+		},
+		Groups: []ReportGroup{
+			{SourceBranch: "dependabot/npm/foo-1.2.3", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "repo-a", Number: 1, Status: "passing", Title: "Bump <foo>", URL: "https://github.com/myorg/repo-a/pull/1"},
+				{Repository: "repo-b", Number: 2, Status: "conflict", Title: "Bump foo", URL: "https://github.com/myorg/repo-b/pull/2"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatHTML, false).WriteReportResult(result, "brief"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<dt>Organization</dt><dd>myorg</dd>",
+		"<dt>Source branch prefixes</dt><dd>dependabot/, renovate/</dd>",
+		"<dt>Repositories scanned</dt><dd>12</dd>",
+		"<dt>Generated</dt><dd>Mon, 02 Mar 2026 09:00:42 UTC (42s)</dd>",
+		"<dt>Totals</dt><dd>1 branch groups, 2 PRs</dd>",
+		`<details class="group" open>`,
+		`<summary><code>dependabot/npm/foo-1.2.3</code>`,
+		`<span class="badge fail">⊘ 1 conflict</span>`,
+		`<a href="https://github.com/myorg/repo-a/pull/1">#1</a>`,
+		"<td>Bump &lt;foo&gt;</td>",
+		`<span class="badge ok">✓ passing</span>`,
+		`<option value="conflict">⊘ conflict</option>`,
+		"<script>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+
+	// The page must be self-contained.
+	for _, external := range []string{"<link", "src=", "@import"} {
+		if strings.Contains(out, external) {
+			t.Errorf("HTML references external asset via %q", external)
+		}
+	}
+}
+
+func TestWriteReportResultHTMLEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatHTML, false).WriteReportResult(&ReportResult{}, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No grouped source branches found.") {
+		t.Errorf("empty HTML report missing placeholder:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), `id="filter"`) {
+		t.Errorf("empty HTML report should not render filter controls")
+	}
+}
+
+func TestHTMLStatusClassMatchesConsoleColors(t *testing.T) {
+	tests := map[string]string{
+		"passing":              "ok",
+		"ready to merge":       "ok",
+		"no checks configured": "ok",
+		"needs-rebase":         "warn",
+		"conflict":             "fail",
+		"checks failing":       "fail",
+		"checks pending":       "muted",
+		"error":                "muted",
+	}
+	for status, want := range tests {
+		if got := htmlStatusClass(status); got != want {
+			t.Errorf("htmlStatusClass(%q) = %q, want %q", status, got, want)
+		}
+	}
+}
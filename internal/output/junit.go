@@ -0,0 +1,118 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test cases for one repository.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is one pull request.
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Skipped   *junitMessage `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+// junitMessage is the body of a failure or skipped element.
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes the result as JUnit XML. Each repository with matching
+// pull requests is a test suite and each pull request a test case: failed
+// actions are failures, skips are skipped, and everything else passes.
+// Repositories skipped as a whole are reported as a single skipped test case.
+func (w *Writer) writeJUnit(result *RunResult) error {
+	root := junitTestSuites{Name: "ghprmerge"}
+	if !result.Metadata.EndTime.IsZero() {
+		root.Time = fmt.Sprintf("%.3f", result.Metadata.EndTime.Sub(result.Metadata.StartTime).Seconds())
+	}
+	timestamp := ""
+	if !result.Metadata.StartTime.IsZero() {
+		timestamp = result.Metadata.StartTime.UTC().Format("2006-01-02T15:04:05")
+	}
+
+	for _, repo := range result.Repositories {
+		suite := junitTestSuite{Name: repo.FullName, Timestamp: timestamp}
+
+		if repo.Skipped {
+			suite.Cases = append(suite.Cases, junitTestCase{
+				Name:      repo.FullName,
+				ClassName: repo.FullName,
+				Skipped:   &junitMessage{Message: repo.SkipReason},
+			})
+		}
+
+		for _, pr := range repo.PullRequests {
+			tc := junitTestCase{
+				Name:      fmt.Sprintf("#%d %s", pr.Number, pr.Title),
+				ClassName: repo.FullName,
+				SystemOut: strings.TrimSpace(fmt.Sprintf("%s\n%s\n%s", pr.Action, pr.Reason, pr.URL)),
+			}
+			switch {
+			case IsFailedAction(pr.Action):
+				tc.Failure = &junitMessage{Message: pr.Reason, Type: string(pr.Action), Text: pr.Reason}
+			case IsSkipAction(pr.Action):
+				message := string(pr.SkipReason)
+				if message == "" {
+					message = strings.TrimPrefix(string(pr.Action), "skip: ")
+				}
+				tc.Skipped = &junitMessage{Message: message, Text: pr.Reason}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		if len(suite.Cases) == 0 {
+			continue
+		}
+		for _, tc := range suite.Cases {
+			suite.Tests++
+			if tc.Failure != nil {
+				suite.Failures++
+			}
+			if tc.Skipped != nil {
+				suite.Skipped++
+			}
+		}
+		root.Tests += suite.Tests
+		root.Failures += suite.Failures
+		root.Skipped += suite.Skipped
+		root.Suites = append(root.Suites, suite)
+	}
+
+	if _, err := fmt.Fprint(w.out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w.out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(root); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w.out)
+	return err
+}
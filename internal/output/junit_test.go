@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteResultJUnit(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	result := &RunResult{
+		Metadata: RunMetadata{Org: "myorg", StartTime: start, EndTime: start.Add(1500 * time.Millisecond)},
+		Repositories: []RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []PullRequestResult{
+				{Number: 12, Title: "Bump foo", URL: "https://github.com/myorg/api/pull/12", Action: ActionMerged, Reason: "successfully merged"},
+				{Number: 13, Title: "Bump bar", Action: ActionMergeFailed, Reason: "merge failed: 405 <not mergeable>"},
+				{Number: 14, Title: "Bump baz", Action: ActionSkipChecksFailing, SkipReason: ReasonChecksFailing, Reason: "build failing"},
+				{Number: 15, Title: "Bump qux", Action: ActionReadyMerge},
+			}},
+			{Name: "empty", FullName: "myorg/empty"},
+			{Name: "late", FullName: "myorg/late", Skipped: true, SkipReason: "repo limit reached"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatJUnit, false).WriteResult(result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("JUnit output missing XML header:\n%s", buf.String())
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("JUnit output is not valid XML: %v\n%s", err, buf.String())
+	}
+
+	if got.Tests != 5 || got.Failures != 1 || got.Skipped != 2 || got.Time != "1.500" {
+		t.Errorf("testsuites totals = tests %d, failures %d, skipped %d, time %s; want 5, 1, 2, 1.500",
+			got.Tests, got.Failures, got.Skipped, got.Time)
+	}
+	if len(got.Suites) != 2 {
+		t.Fatalf("expected 2 suites (repository without PRs omitted), got %d", len(got.Suites))
+	}
+
+	api := got.Suites[0]
+	if api.Name != "myorg/api" || api.Tests != 4 || api.Failures != 1 || api.Skipped != 1 {
+		t.Errorf("unexpected api suite: %+v", api)
+	}
+	if api.Timestamp != "2026-03-02T09:00:00" {
+		t.Errorf("suite timestamp = %q", api.Timestamp)
+	}
+
+	cases := api.Cases
+	if cases[0].Name != "#12 Bump foo" || cases[0].ClassName != "myorg/api" || cases[0].Failure != nil || cases[0].Skipped != nil {
+		t.Errorf("merged PR should pass: %+v", cases[0])
+	}
+	if f := cases[1].Failure; f == nil || f.Message != "merge failed: 405 <not mergeable>" || f.Type != "merge failed" {
+		t.Errorf("failed merge should be a failure with the reason: %+v", cases[1])
+	}
+	if s := cases[2].Skipped; s == nil || s.Message != "checks failing" || s.Text != "build failing" {
+		t.Errorf("skipped PR should carry the skip reason: %+v", cases[2])
+	}
+	if cases[3].Failure != nil || cases[3].Skipped != nil {
+		t.Errorf("ready to merge PR should pass: %+v", cases[3])
+	}
+
+	late := got.Suites[1]
+	if late.Name != "myorg/late" || late.Skipped != 1 || late.Cases[0].Skipped == nil || late.Cases[0].Skipped.Message != "repo limit reached" {
+		t.Errorf("skipped repository should be one skipped case: %+v", late)
+	}
+}
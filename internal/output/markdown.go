@@ -0,0 +1,162 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// StepSummaryPath returns the GitHub Actions step summary file, or "" when not
+// running inside GitHub Actions.
+func StepSummaryPath() string {
+	if os.Getenv("GITHUB_ACTIONS") != "true" {
+		return ""
+	}
+	return os.Getenv("GITHUB_STEP_SUMMARY")
+}
+
+// writeMarkdown writes the result as Markdown: a summary line followed by one
+// table per repository with matching pull requests.
+func (w *Writer) writeMarkdown(result *RunResult) error {
+	c := NewConsole(io.Discard, true, false, true)
+
+	fmt.Fprintf(w.out, "## ghprmerge: %s\n\n", result.Metadata.Mode)
+	fmt.Fprintf(w.out, "**Organization:** `%s`", result.Metadata.Org)
+	if result.Metadata.SourceBranch != "" {
+		fmt.Fprintf(w.out, " · **Source branch:** `%s`", result.Metadata.SourceBranch)
+	}
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "\n%s\n", strings.Join(summaryParts(result.Summary), " · "))
+
+	var skipped []string
+	for _, repo := range result.Repositories {
+		if repo.Skipped {
+			skipped = append(skipped, fmt.Sprintf("`%s` (%s)", repo.FullName, repo.SkipReason))
+			continue
+		}
+		if len(repo.PullRequests) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w.out, "\n### %s\n\n", repo.FullName)
+		fmt.Fprintln(w.out, "| PR | Title | Action | Reason |")
+		fmt.Fprintln(w.out, "|----|-------|--------|--------|")
+		for _, pr := range repo.PullRequests {
+			fmt.Fprintf(w.out, "| %s | %s | %s %s | %s |\n",
+				markdownLink(fmt.Sprintf("#%d", pr.Number), pr.URL),
+				markdownCell(pr.Title),
+				c.getActionSymbol(pr.Action), markdownCell(string(pr.Action)),
+				markdownCell(pr.Reason))
+		}
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(w.out, "\n**Skipped repositories:** %s\n", strings.Join(skipped, ", "))
+	}
+	return nil
+}
+
+// writeReportMarkdown writes the report as Markdown: one table per source
+// branch group, or a single table of groups for brief verbosity.
+func (w *Writer) writeReportMarkdown(result *ReportResult, verbosity string) error {
+	c := NewConsole(io.Discard, true, false, true)
+
+	totalPRs := 0
+	for _, group := range result.Groups {
+		totalPRs += group.Count
+	}
+	fmt.Fprintln(w.out, "## ghprmerge: report")
+	fmt.Fprintf(w.out, "\n%d branch groups · %d PRs\n", len(result.Groups), totalPRs)
+
+	if len(result.Groups) == 0 {
+		fmt.Fprintln(w.out, "\nNo grouped source branches found.")
+	} else if verbosity == "brief" {
+		fmt.Fprintln(w.out, "\n| Source branch | PRs |")
+		fmt.Fprintln(w.out, "|---------------|-----|")
+		for _, group := range result.Groups {
+			fmt.Fprintf(w.out, "| `%s` | %d |\n", markdownCell(group.Name()), group.Count)
+		}
+	} else {
+		w.writeReportGroupsMarkdown(c, result.Groups, verbosity)
+	}
+
+	if result.Comparison != nil {
+		writeReportComparisonMarkdown(w.out, result.Comparison)
+	}
+	return nil
+}
+
+// writeReportGroupsMarkdown writes one table per source branch group.
+func (w *Writer) writeReportGroupsMarkdown(c *Console, groups []ReportGroup, verbosity string) {
+	for _, group := range groups {
+		fmt.Fprintf(w.out, "\n### `%s` (%d PRs)\n\n", markdownCell(group.Name()), group.Count)
+		if verbosity == "verbose" {
+			fmt.Fprintln(w.out, "| Repository | PR | Title | Status |")
+			fmt.Fprintln(w.out, "|------------|----|-------|--------|")
+		} else {
+			fmt.Fprintln(w.out, "| Repository | PR | Status |")
+			fmt.Fprintln(w.out, "|------------|----|--------|")
+		}
+		for _, pr := range group.PullRequests {
+			link := markdownLink(fmt.Sprintf("#%d", pr.Number), pr.URL)
+			status := c.reportStatusSymbol(pr.Status) + " " + markdownCell(pr.Status)
+			if verbosity == "verbose" {
+				fmt.Fprintf(w.out, "| %s | %s | %s | %s |\n", markdownCell(pr.Repository), link, markdownCell(pr.Title), status)
+			} else {
+				fmt.Fprintf(w.out, "| %s | %s | %s |\n", markdownCell(pr.Repository), link, status)
+			}
+		}
+	}
+}
+
+// summaryParts returns the non-zero run summary counters as plain text.
+func summaryParts(summary RunSummary) []string {
+	parts := []string{
+		fmt.Sprintf("%d repos scanned", summary.ReposProcessed),
+		fmt.Sprintf("%d PRs found", summary.CandidatesFound),
+	}
+	counters := []struct {
+		n     int
+		label string
+	}{
+		{summary.MergedSuccess, "merged"},
+		{summary.RebasedSuccess, "rebased"},
+		{summary.ClosedSuccess, "closed"},
+		{summary.RecreateRequested, "recreate requested"},
+		{summary.IgnoredSuccess, "ignored"},
+		{summary.WouldMerge, "would merge"},
+		{summary.WouldRebase, "would rebase"},
+		{summary.WouldClose, "would close"},
+		{summary.WouldRecreate, "would recreate"},
+		{summary.WouldIgnore, "would ignore"},
+		{summary.ReadyToMerge, "ready to merge"},
+		{summary.MergeFailed, "merge failed"},
+		{summary.RebaseFailed, "rebase failed"},
+		{summary.CloseFailed, "close failed"},
+		{summary.RecreateFailed, "recreate failed"},
+		{summary.IgnoreFailed, "ignore failed"},
+		{summary.Skipped, "skipped"},
+	}
+	for _, counter := range counters {
+		if counter.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counter.n, counter.label))
+		}
+	}
+	return parts
+}
+
+// markdownLink returns a Markdown link, or the plain text when url is empty.
+func markdownLink(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+// markdownCell escapes text for use inside a Markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", " ")
+}
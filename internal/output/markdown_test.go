@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteResultMarkdown(t *testing.T) {
+	result := &RunResult{
+		Metadata: RunMetadata{Org: "myorg", SourceBranch: "dependabot/", Mode: "merge mode"},
+		Repositories: []RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []PullRequestResult{
+				{Number: 12, Title: "Bump a | b", URL: "https://github.com/myorg/api/pull/12", Action: ActionMerged, Reason: "successfully merged"},
+				{Number: 13, Title: "Bump c", URL: "https://github.com/myorg/api/pull/13", Action: ActionSkipChecksFailing, Reason: "build:\nfailing"},
+			}},
+			{Name: "empty", FullName: "myorg/empty"},
+			{Name: "late", FullName: "myorg/late", Skipped: true, SkipReason: "repo limit reached"},
+		},
+		Summary: RunSummary{ReposProcessed: 2, CandidatesFound: 2, MergedSuccess: 1, Skipped: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := NewFormatWriter(&buf, FormatMarkdown, false).WriteResult(result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## ghprmerge: merge mode",
+		"**Organization:** `myorg` · **Source branch:** `dependabot/`",
+		"2 repos scanned · 2 PRs found · 1 merged · 1 skipped",
+		"### myorg/api",
+		"| [#12](https://github.com/myorg/api/pull/12) | Bump a \\| b | ✓ merged | successfully merged |",
+		"| [#13](https://github.com/myorg/api/pull/13) | Bump c | ⊘ skip: checks failing | build: failing |",
+		"**Skipped repositories:** `myorg/late` (repo limit reached)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "myorg/empty") {
+		t.Errorf("markdown includes repository without pull requests:\n%s", out)
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("markdown contains ANSI escape codes:\n%s", out)
+	}
+}
+
+func TestWriteReportResultMarkdown(t *testing.T) {
+	result := &ReportResult{
+		Groups: []ReportGroup{
+			{SourceBranch: "dependabot/npm/foo-1.2.3", Count: 2, PullRequests: []ReportPullRequest{
+				{Repository: "repo-a", Number: 1, Status: "passing", Title: "Bump foo", URL: "https://github.com/org/repo-a/pull/1"},
+				{Repository: "repo-b", Number: 2, Status: "needs-rebase", Title: "Bump foo", URL: "https://github.com/org/repo-b/pull/2"},
+			}},
+		},
+	}
+
+	tests := []struct {
+		verbosity string
+		want      []string
+	}{
+		{"brief", []string{"1 branch groups · 2 PRs", "| `dependabot/npm/foo-1.2.3` | 2 |"}},
+		{"standard", []string{"### `dependabot/npm/foo-1.2.3` (2 PRs)", "| repo-b | [#2](https://github.com/org/repo-b/pull/2) | ↻ needs-rebase |"}},
+		{"verbose", []string{"| Repository | PR | Title | Status |", "| repo-a | [#1](https://github.com/org/repo-a/pull/1) | Bump foo | ✓ passing |"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.verbosity, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewFormatWriter(&buf, FormatMarkdown, false).WriteReportResult(result, tt.verbosity); err != nil {
+				t.Fatalf("WriteReportResult() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("markdown missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestStepSummaryPath(t *testing.T) {
+	t.Setenv("GITHUB_STEP_SUMMARY", "/tmp/summary.md")
+
+	t.Setenv("GITHUB_ACTIONS", "")
+	if got := StepSummaryPath(); got != "" {
+		t.Errorf("StepSummaryPath() outside Actions = %q, want empty", got)
+	}
+
+	t.Setenv("GITHUB_ACTIONS", "true")
+	if got := StepSummaryPath(); got != "/tmp/summary.md" {
+		t.Errorf("StepSummaryPath() = %q, want /tmp/summary.md", got)
+	}
+}
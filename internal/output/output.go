@@ -4,6 +4,7 @@ package output
 import (
 	"encoding/json"
 	"io"
+	"strings"
 	"time"
 )
 
@@ -12,10 +13,12 @@ type Action string
 
 const (
 	// Analysis mode actions (what would happen)
-	ActionWouldMerge  Action = "would merge"
-	ActionWouldRebase Action = "would rebase"
-	ActionWouldClose  Action = "would close"
-	ActionReadyMerge  Action = "ready to merge" // Ready but merge not enabled
+	ActionWouldMerge    Action = "would merge"
+	ActionWouldRebase   Action = "would rebase"
+	ActionWouldClose    Action = "would close"
+	ActionWouldRecreate Action = "would recreate"
+	ActionWouldIgnore   Action = "would ignore"
+	ActionReadyMerge    Action = "ready to merge" // Ready but merge not enabled
 
 	// Execution mode actions (what happened)
 	ActionMerged       Action = "merged"
@@ -25,6 +28,12 @@ const (
 	ActionClosed       Action = "closed"
 	ActionCloseFailed  Action = "close failed"
 
+	// Bot command actions (what happened)
+	ActionRecreateRequested Action = "recreate requested"
+	ActionRecreateFailed    Action = "recreate failed"
+	ActionIgnored           Action = "ignored"
+	ActionIgnoreFailed      Action = "ignore failed"
+
 	// Skip reasons
 	ActionSkipNotTargetingDefault Action = "skip: not targeting default branch"
 	ActionSkipBranchNoMatch       Action = "skip: branch does not match source pattern"
@@ -38,6 +47,10 @@ const (
 	ActionSkipPermissions         Action = "skip: insufficient permissions"
 	ActionSkipAPIError            Action = "skip: API error"
 	ActionSkipRepoLimit           Action = "skip: repo limit reached"
+	ActionSkipDeselected          Action = "skip: deselected by user"
+	ActionSkipInterrupted         Action = "skip: interrupted"
+	ActionSkipNotStale            Action = "skip: not stale"
+	ActionSkipClosedOutside       Action = "skip: closed outside ghprmerge"
 )
 
 // SkipReason represents a categorized skip reason for summary grouping.
@@ -56,6 +69,10 @@ const (
 	ReasonPermissions         SkipReason = "insufficient permissions"
 	ReasonAPIError            SkipReason = "API error"
 	ReasonRepoLimit           SkipReason = "repo limit reached"
+	ReasonDeselected          SkipReason = "deselected by user"
+	ReasonInterrupted         SkipReason = "interrupted"
+	ReasonNotStale            SkipReason = "not stale"
+	ReasonClosedOutside       SkipReason = "closed outside ghprmerge"
 )
 
 // PullRequestResult represents the result for a single pull request.
@@ -68,6 +85,12 @@ type PullRequestResult struct {
 	Action           Action     `json:"action"`
 	Reason           string     `json:"reason,omitempty"`
 	SkipReason       SkipReason `json:"skip_reason,omitempty"`
+	RebaseStrategy   string     `json:"rebase_strategy,omitempty"`
+	Author           string     `json:"author,omitempty"`
+	Dependabot       bool       `json:"dependabot,omitempty"`
+	Labels           []string   `json:"labels,omitempty"`
+	CreatedAt        time.Time  `json:"created_at,omitzero"`
+	UpdatedAt        time.Time  `json:"updated_at,omitzero"`
 }
 
 // RepositoryResult represents the results for a single repository.
@@ -82,9 +105,10 @@ type RepositoryResult struct {
 
 // RunResult represents the complete result of a run.
 type RunResult struct {
-	Metadata     RunMetadata        `json:"metadata"`
-	Repositories []RepositoryResult `json:"repositories"`
-	Summary      RunSummary         `json:"summary"`
+	SchemaVersion int                `json:"schema_version"`
+	Metadata      RunMetadata        `json:"metadata"`
+	Repositories  []RepositoryResult `json:"repositories"`
+	Summary       RunSummary         `json:"summary"`
 }
 
 // RunMetadata contains metadata about the run.
@@ -99,53 +123,145 @@ type RunMetadata struct {
 	RepoLimitDesc string    `json:"repo_limit_desc,omitempty"`
 	StartTime     time.Time `json:"start_time"`
 	EndTime       time.Time `json:"end_time"`
+	Interrupted   bool      `json:"interrupted,omitempty"`
+	Resumed       bool      `json:"resumed,omitempty"`
 }
 
 // RunSummary contains summary statistics for the run.
 type RunSummary struct {
-	ReposProcessed  int            `json:"repos_processed"`
-	ReposSkipped    int            `json:"repos_skipped"`
-	CandidatesFound int            `json:"candidates_found"`
-	MergedSuccess   int            `json:"merged_success"`
-	MergeFailed     int            `json:"merge_failed"`
-	RebasedSuccess  int            `json:"rebased_success"`
-	RebaseFailed    int            `json:"rebase_failed"`
-	ClosedSuccess   int            `json:"closed_success"`
-	CloseFailed     int            `json:"close_failed"`
-	WouldMerge      int            `json:"would_merge,omitempty"`
-	WouldRebase     int            `json:"would_rebase,omitempty"`
-	WouldClose      int            `json:"would_close,omitempty"`
-	ReadyToMerge    int            `json:"ready_to_merge,omitempty"`
-	Skipped         int            `json:"skipped"`
-	SkippedByReason map[string]int `json:"skipped_by_reason,omitempty"`
+	ReposProcessed    int            `json:"repos_processed"`
+	ReposSkipped      int            `json:"repos_skipped"`
+	CandidatesFound   int            `json:"candidates_found"`
+	MergedSuccess     int            `json:"merged_success"`
+	MergeFailed       int            `json:"merge_failed"`
+	RebasedSuccess    int            `json:"rebased_success"`
+	RebaseFailed      int            `json:"rebase_failed"`
+	ClosedSuccess     int            `json:"closed_success"`
+	CloseFailed       int            `json:"close_failed"`
+	WouldMerge        int            `json:"would_merge,omitempty"`
+	WouldRebase       int            `json:"would_rebase,omitempty"`
+	WouldClose        int            `json:"would_close,omitempty"`
+	RecreateRequested int            `json:"recreate_requested,omitempty"`
+	RecreateFailed    int            `json:"recreate_failed,omitempty"`
+	WouldRecreate     int            `json:"would_recreate,omitempty"`
+	IgnoredSuccess    int            `json:"ignored_success,omitempty"`
+	IgnoreFailed      int            `json:"ignore_failed,omitempty"`
+	WouldIgnore       int            `json:"would_ignore,omitempty"`
+	ReadyToMerge      int            `json:"ready_to_merge,omitempty"`
+	Skipped           int            `json:"skipped"`
+	SkippedByReason   map[string]int `json:"skipped_by_reason,omitempty"`
+}
+
+// IsPendingAction reports whether the action is planned but not yet executed.
+func IsPendingAction(action Action) bool {
+	switch action {
+	case ActionWouldMerge, ActionWouldRebase, ActionWouldClose, ActionWouldRecreate, ActionWouldIgnore:
+		return true
+	}
+	return false
+}
+
+// IsCompletedAction reports whether the action records an executed mutation, successful or not.
+func IsCompletedAction(action Action) bool {
+	switch action {
+	case ActionMerged, ActionMergeFailed, ActionRebased, ActionRebaseFailed, ActionClosed, ActionCloseFailed,
+		ActionRecreateRequested, ActionRecreateFailed, ActionIgnored, ActionIgnoreFailed:
+		return true
+	}
+	return false
+}
+
+// IsFailedAction reports whether the action records a mutation that failed.
+func IsFailedAction(action Action) bool {
+	switch action {
+	case ActionMergeFailed, ActionRebaseFailed, ActionCloseFailed, ActionRecreateFailed, ActionIgnoreFailed:
+		return true
+	}
+	return false
+}
+
+// IsSkipAction reports whether the action records a skipped pull request.
+func IsSkipAction(action Action) bool {
+	return strings.HasPrefix(string(action), "skip:")
 }
 
+// HasPendingActions reports whether the summary counts any planned actions.
+func (s RunSummary) HasPendingActions() bool {
+	return s.WouldMerge > 0 || s.WouldRebase > 0 || s.WouldClose > 0 || s.WouldRecreate > 0 || s.WouldIgnore > 0
+}
+
+// Format selects how results are written to stdout.
+type Format string
+
+const (
+	FormatText     Format = "text"
+	FormatJSON     Format = "json"
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html" // report only
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatJUnit    Format = "junit"  // merge, rebase, and close only
+	FormatNDJSON   Format = "ndjson" // merge, rebase, and close only
+)
+
+// Formats lists the supported output formats.
+var Formats = []Format{FormatText, FormatJSON, FormatMarkdown, FormatHTML, FormatCSV, FormatTSV, FormatJUnit, FormatNDJSON}
+
 // Writer handles output formatting.
 type Writer struct {
-	out      io.Writer
-	jsonMode bool
-	noColor  bool
+	out     io.Writer
+	format  Format
+	noColor bool
+	casing  JSONCasing
 }
 
-// NewWriter creates a new Writer.
+// NewWriter creates a new Writer that writes JSON or human-readable text.
 func NewWriter(out io.Writer, jsonMode bool, noColor bool) *Writer {
+	format := FormatText
+	if jsonMode {
+		format = FormatJSON
+	}
+	return NewFormatWriter(out, format, noColor)
+}
+
+// NewFormatWriter creates a new Writer for the given format.
+func NewFormatWriter(out io.Writer, format Format, noColor bool) *Writer {
 	return &Writer{
-		out:      out,
-		jsonMode: jsonMode,
-		noColor:  noColor,
+		out:     out,
+		format:  format,
+		noColor: noColor,
+		casing:  CasingCamel,
 	}
 }
 
+// SetJSONCasing selects the key casing of report JSON output.
+func (w *Writer) SetJSONCasing(casing JSONCasing) {
+	w.casing = casing
+}
+
 // WriteResult writes the complete run result.
 func (w *Writer) WriteResult(result *RunResult) error {
-	if w.jsonMode {
+	switch w.format {
+	case FormatJSON:
 		return w.writeJSON(result)
+	case FormatMarkdown:
+		return w.writeMarkdown(result)
+	case FormatCSV, FormatTSV:
+		return w.writeRunDelimited(result)
+	case FormatJUnit:
+		return w.writeJUnit(result)
+	case FormatNDJSON:
+		// Earlier events were streamed during the run; finish with the summary.
+		NewEventStream(w.out).RunFinished(result)
+		return nil
+	default:
+		return w.writeHuman(result)
 	}
-	return w.writeHuman(result)
 }
 
 // writeJSON writes the result as JSON.
 func (w *Writer) writeJSON(result *RunResult) error {
+	result.SchemaVersion = SchemaVersion
 	encoder := json.NewEncoder(w.out)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(result)
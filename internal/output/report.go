@@ -5,64 +5,434 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"time"
 )
 
 // ReportPullRequest represents a single PR in a report group.
 type ReportPullRequest struct {
-	Repository string `json:"repository"`
-	Number     int    `json:"number"`
-	Status     string `json:"status"`
-	Title      string `json:"title,omitempty"`
-	URL        string `json:"url,omitempty"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Status     string    `json:"status"`
+	Title      string    `json:"title,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	CreatedAt  time.Time `json:"createdAt,omitzero"`
+	UpdatedAt  time.Time `json:"updatedAt,omitzero"`
+	// FailingSince is when the failing check finished, set only when the
+	// status is "checks failing".
+	FailingSince time.Time `json:"failingSince,omitzero"`
 }
 
-// ReportGroup represents a group of PRs sharing the same source branch.
+// ReportView selects how the report lists pull requests.
+type ReportView string
+
+const (
+	// ViewGroups lists groups of PRs, largest first.
+	ViewGroups ReportView = "groups"
+	// ViewStale lists PRs open longer than a threshold, oldest first, in a
+	// single group named "stale".
+	ViewStale ReportView = "stale"
+)
+
+// StaleGroup is the name of the only group in a stale view.
+const StaleGroup = "stale"
+
+// DefaultStaleAfter is the age at which the stale view lists a PR.
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// StaleSince returns the time a PR's staleness is measured from: the earlier
+// of when it was opened and when its checks started failing, ignoring either
+// when it is unknown. It is zero when both are.
+func StaleSince(createdAt, failingSince time.Time) time.Time {
+	if createdAt.IsZero() || (!failingSince.IsZero() && failingSince.Before(createdAt)) {
+		return failingSince
+	}
+	return createdAt
+}
+
+// IsStale reports whether a PR had been open, or failing, for at least
+// staleAfter at the given time.
+func IsStale(createdAt, failingSince time.Time, staleAfter time.Duration, at time.Time) bool {
+	since := StaleSince(createdAt, failingSince)
+	return !since.IsZero() && at.Sub(since) >= staleAfter
+}
+
+// FormatAge renders an age as days and hours ("3d 4h", "12d"), hours and
+// minutes, or minutes.
+func FormatAge(age time.Duration) string {
+	age = age.Round(time.Minute)
+	days := int(age / (24 * time.Hour))
+	age -= time.Duration(days) * 24 * time.Hour
+	hours := int(age / time.Hour)
+	minutes := int(age % time.Hour / time.Minute)
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// ReportGroupBy selects what the report groups pull requests by.
+type ReportGroupBy string
+
+const (
+	GroupByBranch         ReportGroupBy = "branch"
+	GroupByPackage        ReportGroupBy = "package"
+	GroupByPackageVersion ReportGroupBy = "package-version"
+	GroupByEcosystem      ReportGroupBy = "ecosystem"
+	GroupByAuthor         ReportGroupBy = "author"
+	GroupByStatus         ReportGroupBy = "status"
+)
+
+// ReportGroupings lists the valid --group-by values.
+var ReportGroupings = []ReportGroupBy{
+	GroupByBranch, GroupByPackage, GroupByPackageVersion, GroupByEcosystem, GroupByAuthor, GroupByStatus,
+}
+
+// ReportGroup represents a group of PRs sharing the same source branch, or
+// the same Key when the report is grouped by something else.
 type ReportGroup struct {
 	SourceBranch string              `json:"sourceBranch"`
+	Key          string              `json:"key,omitempty"`
 	Count        int                 `json:"count"`
 	PullRequests []ReportPullRequest `json:"pullRequests"`
 }
 
+// Name returns what the group's PRs have in common: the source branch, or
+// the key when the report is not grouped by branch.
+func (g ReportGroup) Name() string {
+	if g.Key != "" {
+		return g.Key
+	}
+	return g.SourceBranch
+}
+
+// StatusCounts returns how many of the group's PRs have each status. PRs
+// without an evaluated status, as in brief text reports, are not counted.
+func (g ReportGroup) StatusCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, pr := range g.PullRequests {
+		if pr.Status != "" {
+			counts[pr.Status]++
+		}
+	}
+	return counts
+}
+
+// ReportSummary totals the groups and pull requests in a report.
+type ReportSummary struct {
+	TotalGroups       int                  `json:"totalGroups"`
+	TotalPullRequests int                  `json:"totalPullRequests"`
+	ByStatus          map[string]int       `json:"byStatus,omitempty"`
+	Groups            []ReportGroupSummary `json:"groups"`
+}
+
+// ReportGroupSummary is the status breakdown of one report group.
+type ReportGroupSummary struct {
+	Group    string         `json:"group"`
+	Count    int            `json:"count"`
+	ByStatus map[string]int `json:"byStatus,omitempty"`
+}
+
+// SummarizeReport totals the groups, in the same order.
+func SummarizeReport(groups []ReportGroup) *ReportSummary {
+	summary := &ReportSummary{
+		TotalGroups: len(groups),
+		ByStatus:    make(map[string]int),
+		Groups:      make([]ReportGroupSummary, 0, len(groups)),
+	}
+	for _, group := range groups {
+		counts := group.StatusCounts()
+		for status, n := range counts {
+			summary.ByStatus[status] += n
+		}
+		summary.TotalPullRequests += len(group.PullRequests)
+		summary.Groups = append(summary.Groups, ReportGroupSummary{Group: group.Name(), Count: len(group.PullRequests), ByStatus: counts})
+	}
+	return summary
+}
+
+// reportStatusOrder is the order statuses are listed in, from ready to
+// blocked. Other statuses follow alphabetically.
+var reportStatusOrder = []string{
+	"passing", "no checks configured", "needs-rebase", "conflict", "checks failing", "checks pending", "error",
+}
+
+// sortedStatuses returns the statuses in counts in display order.
+func sortedStatuses(counts map[string]int) []string {
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b string) int {
+		i, j := slices.Index(reportStatusOrder, a), slices.Index(reportStatusOrder, b)
+		switch {
+		case i >= 0 && j >= 0:
+			return i - j
+		case i >= 0:
+			return -1
+		case j >= 0:
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	return statuses
+}
+
+// ReportMetadata describes the report run and the filters it used.
+type ReportMetadata struct {
+	Org                  string    `json:"org"`
+	SourceBranchPrefixes []string  `json:"sourceBranchPrefixes,omitempty"`
+	MinGroupSize         int       `json:"minGroupSize"`
+	Author               string    `json:"author,omitempty"`
+	Labels               []string  `json:"labels,omitempty"`
+	ExcludeLabels        []string  `json:"excludeLabels,omitempty"`
+	MinAge               string    `json:"minAge,omitempty"`
+	MaxAge               string    `json:"maxAge,omitempty"`
+	Repos                []string  `json:"repos,omitempty"`
+	RepoLimit            int       `json:"repoLimit,omitempty"`
+	GroupBy              string    `json:"groupBy,omitempty"`
+	View                 string    `json:"view,omitempty"`
+	StaleAfter           string    `json:"staleAfter,omitempty"`
+	ReposScanned         int       `json:"reposScanned"`
+	StartTime            time.Time `json:"startTime"`
+	EndTime              time.Time `json:"endTime"`
+}
+
 // ReportResult represents the complete report output.
 type ReportResult struct {
-	Groups []ReportGroup `json:"groups"`
+	SchemaVersion int             `json:"schema_version"`
+	Metadata      *ReportMetadata `json:"metadata,omitempty"`
+	Groups        []ReportGroup   `json:"groups"`
+	Summary       *ReportSummary  `json:"summary,omitempty"`
+	// Comparison is set by report --compare.
+	Comparison *ReportComparison `json:"comparison,omitempty"`
+	// BelowMinimum counts the PRs of the groups left out by --min-group-size,
+	// so a comparison can tell a group that shrank below it from one that
+	// is gone. It is not written.
+	BelowMinimum map[string]int `json:"-"`
+}
+
+// Select returns the pull request numbers in the named groups, keyed by
+// repository name. Every name must match a group in the report.
+func (r *ReportResult) Select(names []string) (map[string][]int, error) {
+	selected := make(map[string][]int)
+	for _, name := range names {
+		found := false
+		for _, group := range r.Groups {
+			if group.Name() != name {
+				continue
+			}
+			found = true
+			for _, pr := range group.PullRequests {
+				if !slices.Contains(selected[pr.Repository], pr.Number) {
+					selected[pr.Repository] = append(selected[pr.Repository], pr.Number)
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("group %q is not in the report", name)
+		}
+	}
+	return selected, nil
+}
+
+// View returns how the report lists pull requests.
+func (r *ReportResult) View() ReportView {
+	if r.Metadata == nil || r.Metadata.View == "" {
+		return ViewGroups
+	}
+	return ReportView(r.Metadata.View)
+}
+
+// GroupBy returns what the report's groups were formed by.
+func (r *ReportResult) GroupBy() ReportGroupBy {
+	if r.Metadata == nil || r.Metadata.GroupBy == "" {
+		return GroupByBranch
+	}
+	return ReportGroupBy(r.Metadata.GroupBy)
 }
 
-// WriteReportResult writes the report result in JSON or human-readable format.
+// WriteReportResult writes the report result in the writer's format.
 func (w *Writer) WriteReportResult(result *ReportResult, verbosity string) error {
-	if w.jsonMode {
+	switch w.format {
+	case FormatJSON:
 		return w.writeReportJSON(result)
+	case FormatMarkdown:
+		return w.writeReportMarkdown(result, verbosity)
+	case FormatHTML:
+		return w.writeReportHTML(result)
+	case FormatCSV, FormatTSV:
+		return w.writeReportDelimited(result)
+	default:
+		return w.writeReportHuman(result, verbosity)
+	}
+}
+
+// reportMetadataSnake is ReportMetadata with snake_case keys.
+type reportMetadataSnake struct {
+	Org                  string    `json:"org"`
+	SourceBranchPrefixes []string  `json:"source_branch_prefixes,omitempty"`
+	MinGroupSize         int       `json:"min_group_size"`
+	Author               string    `json:"author,omitempty"`
+	Labels               []string  `json:"labels,omitempty"`
+	ExcludeLabels        []string  `json:"exclude_labels,omitempty"`
+	MinAge               string    `json:"min_age,omitempty"`
+	MaxAge               string    `json:"max_age,omitempty"`
+	Repos                []string  `json:"repos,omitempty"`
+	RepoLimit            int       `json:"repo_limit,omitempty"`
+	GroupBy              string    `json:"group_by,omitempty"`
+	View                 string    `json:"view,omitempty"`
+	StaleAfter           string    `json:"stale_after,omitempty"`
+	ReposScanned         int       `json:"repos_scanned"`
+	StartTime            time.Time `json:"start_time"`
+	EndTime              time.Time `json:"end_time"`
+}
+
+// reportPullRequestSnake is ReportPullRequest with snake_case keys.
+type reportPullRequestSnake struct {
+	Repository   string    `json:"repository"`
+	Number       int       `json:"number"`
+	Status       string    `json:"status"`
+	Title        string    `json:"title,omitempty"`
+	URL          string    `json:"url,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitzero"`
+	UpdatedAt    time.Time `json:"updated_at,omitzero"`
+	FailingSince time.Time `json:"failing_since,omitzero"`
+}
+
+// reportGroupSnake is ReportGroup with snake_case keys.
+type reportGroupSnake struct {
+	SourceBranch string                   `json:"source_branch"`
+	Key          string                   `json:"key,omitempty"`
+	Count        int                      `json:"count"`
+	PullRequests []reportPullRequestSnake `json:"pull_requests"`
+}
+
+// snakeCase converts the group to its snake_case form.
+func (g ReportGroup) snakeCase() reportGroupSnake {
+	snake := reportGroupSnake{SourceBranch: g.SourceBranch, Key: g.Key, Count: g.Count,
+		PullRequests: make([]reportPullRequestSnake, 0, len(g.PullRequests))}
+	for _, pr := range g.PullRequests {
+		snake.PullRequests = append(snake.PullRequests, reportPullRequestSnake(pr))
+	}
+	return snake
+}
+
+// camelCase converts a snake_case group back to a ReportGroup.
+func (g reportGroupSnake) camelCase() ReportGroup {
+	group := ReportGroup{SourceBranch: g.SourceBranch, Key: g.Key, Count: g.Count,
+		PullRequests: make([]ReportPullRequest, 0, len(g.PullRequests))}
+	for _, pr := range g.PullRequests {
+		group.PullRequests = append(group.PullRequests, ReportPullRequest(pr))
+	}
+	return group
+}
+
+// reportSummarySnake is ReportSummary with snake_case keys.
+type reportSummarySnake struct {
+	TotalGroups       int                       `json:"total_groups"`
+	TotalPullRequests int                       `json:"total_pull_requests"`
+	ByStatus          map[string]int            `json:"by_status,omitempty"`
+	Groups            []reportGroupSummarySnake `json:"groups"`
+}
+
+// reportGroupSummarySnake is ReportGroupSummary with snake_case keys.
+type reportGroupSummarySnake struct {
+	Group    string         `json:"group"`
+	Count    int            `json:"count"`
+	ByStatus map[string]int `json:"by_status,omitempty"`
+}
+
+// reportResultSnake is ReportResult with snake_case keys, matching the run output.
+type reportResultSnake struct {
+	SchemaVersion int                    `json:"schema_version"`
+	Metadata      *reportMetadataSnake   `json:"metadata,omitempty"`
+	Groups        []reportGroupSnake     `json:"groups"`
+	Summary       *reportSummarySnake    `json:"summary,omitempty"`
+	Comparison    *reportComparisonSnake `json:"comparison,omitempty"`
+}
+
+// snakeCase converts the report to its snake_case form.
+func (r *ReportResult) snakeCase() *reportResultSnake {
+	snake := &reportResultSnake{
+		SchemaVersion: r.SchemaVersion,
+		Groups:        make([]reportGroupSnake, 0, len(r.Groups)),
+	}
+	if r.Metadata != nil {
+		metadata := reportMetadataSnake(*r.Metadata)
+		snake.Metadata = &metadata
+	}
+	for _, group := range r.Groups {
+		snake.Groups = append(snake.Groups, group.snakeCase())
+	}
+	if r.Summary != nil {
+		snake.Summary = &reportSummarySnake{
+			TotalGroups:       r.Summary.TotalGroups,
+			TotalPullRequests: r.Summary.TotalPullRequests,
+			ByStatus:          r.Summary.ByStatus,
+			Groups:            make([]reportGroupSummarySnake, 0, len(r.Summary.Groups)),
+		}
+		for _, group := range r.Summary.Groups {
+			snake.Summary.Groups = append(snake.Summary.Groups, reportGroupSummarySnake(group))
+		}
+	}
+	if r.Comparison != nil {
+		snake.Comparison = r.Comparison.snakeCase()
 	}
-	return w.writeReportHuman(result, verbosity)
+	return snake
 }
 
 // writeReportJSON writes the report as JSON.
 func (w *Writer) writeReportJSON(result *ReportResult) error {
+	result.SchemaVersion = SchemaVersion
 	encoder := json.NewEncoder(w.out)
 	encoder.SetIndent("", "  ")
+	if w.casing == CasingSnake {
+		return encoder.Encode(result.snakeCase())
+	}
 	return encoder.Encode(result)
 }
 
 // writeReportHuman writes the report in human-readable format.
 func (w *Writer) writeReportHuman(result *ReportResult, verbosity string) error {
+	c := NewConsole(w.out, w.noColor, false, false)
 	if len(result.Groups) == 0 {
 		fmt.Fprintln(w.out, "No grouped source branches found.")
-		return nil
+	} else {
+		c.PrintReport(result, verbosity)
+	}
+	if result.Comparison != nil {
+		c.PrintReportComparison(result.Comparison)
+	}
+	if result.Summary != nil && len(result.Groups) > 0 {
+		fmt.Fprintln(w.out)
+		c.PrintReportSummary(result.Summary)
 	}
-
-	c := NewConsole(w.out, w.noColor, false, false)
-	c.PrintReport(result, verbosity)
 	return nil
 }
 
-// PrintReport prints the report output to the console.
+// PrintReport prints the report output to the console. Verbose reports and
+// the stale view show each PR's age as of the end of the scan.
 func (c *Console) PrintReport(result *ReportResult, verbosity string) {
+	showAge := verbosity == "verbose" || result.View() == ViewStale
+	now := time.Now()
+	if result.Metadata != nil && !result.Metadata.EndTime.IsZero() {
+		now = result.Metadata.EndTime
+	}
 	for i, group := range result.Groups {
 		if i > 0 {
 			fmt.Fprintln(c.w)
 		}
-		c.printReportGroup(group, verbosity)
+		c.printReportGroup(group, verbosity, showAge, now)
 	}
 }
 
@@ -73,15 +443,20 @@ func (c *Console) PrintReportHeader(org string, repoCount int) {
 }
 
 // printReportGroup prints a single report group.
-func (c *Console) printReportGroup(group ReportGroup, verbosity string) {
+func (c *Console) printReportGroup(group ReportGroup, verbosity string, showAge bool, now time.Time) {
 	// Branch name and count
-	fmt.Fprintf(c.w, "%s %s\n", c.Bold(group.SourceBranch), c.Dim(fmt.Sprintf("(%d PRs)", group.Count)))
-
 	if verbosity == "brief" {
+		fmt.Fprintf(c.w, "%s %s\n", c.Bold(group.Name()), c.Dim(fmt.Sprintf("(%d PRs)", group.Count)))
 		return
 	}
 
-	// Standard and verbose: show each PR
+	// Standard and verbose: add the status breakdown and show each PR
+	header := fmt.Sprintf("%s %s", c.Bold(group.Name()), c.Dim(fmt.Sprintf("(%d PRs)", group.Count)))
+	if counts := group.StatusCounts(); len(counts) > 0 {
+		header += " " + c.Dim("·") + " " + strings.Join(c.reportStatusCounts(counts), ", ")
+	}
+	fmt.Fprintln(c.w, header)
+
 	for _, pr := range group.PullRequests {
 		symbol := c.reportStatusSymbol(pr.Status)
 		colored := c.reportStatusColor(symbol, pr.Status)
@@ -91,10 +466,28 @@ func (c *Console) printReportGroup(group ReportGroup, verbosity string) {
 			line += " " + truncateString(pr.Title, 50)
 		}
 		line += " " + c.reportStatusColor(pr.Status, pr.Status)
+		if showAge {
+			if age := reportAge(pr, now); age != "" {
+				line += " " + c.Dim(age)
+			}
+		}
 		fmt.Fprintln(c.w, line)
 	}
 }
 
+// reportAge describes how long a PR has been open and, when its checks are
+// failing, for how long, e.g. "open 12d, failing 3d".
+func reportAge(pr ReportPullRequest, now time.Time) string {
+	var parts []string
+	if !pr.CreatedAt.IsZero() {
+		parts = append(parts, "open "+FormatAge(now.Sub(pr.CreatedAt)))
+	}
+	if !pr.FailingSince.IsZero() {
+		parts = append(parts, "failing "+FormatAge(now.Sub(pr.FailingSince)))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // reportStatusSymbol returns a symbol for the report status.
 func (c *Console) reportStatusSymbol(status string) string {
 	switch status {
@@ -125,19 +518,32 @@ func (c *Console) reportStatusColor(text string, status string) string {
 	}
 }
 
-// PrintReportSummary prints the report summary line.
-func (c *Console) PrintReportSummary(totalGroups, totalPRs int) {
+// PrintReportSummary prints the report summary line: the totals followed by
+// the number of PRs with each status.
+func (c *Console) PrintReportSummary(summary *ReportSummary) {
 	fmt.Fprintf(c.w, "%s\n", c.Dim("────────────────────────────────────────────────────"))
 	parts := []string{
-		fmt.Sprintf("%d branch groups", totalGroups),
-		fmt.Sprintf("%d PRs", totalPRs),
+		fmt.Sprintf("%d groups", summary.TotalGroups),
+		fmt.Sprintf("%d PRs", summary.TotalPullRequests),
 	}
+	parts = append(parts, c.reportStatusCounts(summary.ByStatus)...)
 	fmt.Fprintf(c.w, "%s\n", strings.Join(parts, " │ "))
 }
 
+// reportStatusCounts formats each status count as "2 passing", colored by
+// status, in display order.
+func (c *Console) reportStatusCounts(counts map[string]int) []string {
+	statuses := sortedStatuses(counts)
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = c.reportStatusColor(fmt.Sprintf("%d %s", counts[status], status), status)
+	}
+	return parts
+}
+
 // FormatReportEmptyJSON returns an empty report JSON result with an empty groups array.
 func FormatReportEmptyJSON(w io.Writer) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(&ReportResult{Groups: []ReportGroup{}})
+	return encoder.Encode(&ReportResult{SchemaVersion: SchemaVersion, Groups: []ReportGroup{}, Summary: SummarizeReport(nil)})
 }
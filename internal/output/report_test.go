@@ -3,8 +3,10 @@ package output
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestWriteReportResultJSON(t *testing.T) {
@@ -201,3 +203,191 @@ func TestFormatReportEmptyJSON(t *testing.T) {
 		t.Errorf("expected empty groups array")
 	}
 }
+
+func TestWriteReportResultJSONSnakeCase(t *testing.T) {
+	result := &ReportResult{
+		Metadata: &ReportMetadata{Org: "myorg", MinGroupSize: 2, ReposScanned: 3},
+		Groups: []ReportGroup{
+			{SourceBranch: "dependabot/foo", Count: 1, PullRequests: []ReportPullRequest{{Repository: "repo-a", Number: 1, Status: "passing"}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, true, false)
+	w.SetJSONCasing(CasingSnake)
+	if err := w.WriteReportResult(result, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{`"schema_version": 1`, `"source_branch": "dependabot/foo"`, `"pull_requests": [`, `"min_group_size": 2`, `"repos_scanned": 3`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("snake_case report missing %s:\n%s", want, out)
+		}
+	}
+	for _, camel := range []string{"sourceBranch", "pullRequests", "minGroupSize"} {
+		if strings.Contains(out, camel) {
+			t.Errorf("snake_case report contains camelCase key %s", camel)
+		}
+	}
+}
+
+func TestReportResultSelect(t *testing.T) {
+	report := &ReportResult{
+		Groups: []ReportGroup{
+			{SourceBranch: "dependabot/npm/lodash", PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 4},
+				{Repository: "web", Number: 9},
+			}},
+			{SourceBranch: "dependabot/npm/axios", PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 5},
+			}},
+			{Key: "renovate[bot]", PullRequests: []ReportPullRequest{
+				{Repository: "api", Number: 4},
+			}},
+		},
+	}
+
+	selection, err := report.Select([]string{"dependabot/npm/lodash", "renovate[bot]"})
+	if err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+	want := map[string][]int{"api": {4}, "web": {9}}
+	if !reflect.DeepEqual(selection, want) {
+		t.Errorf("Select() = %v, want %v", selection, want)
+	}
+
+	if _, err := report.Select([]string{"dependabot/npm/react"}); err == nil || !strings.Contains(err.Error(), `"dependabot/npm/react"`) {
+		t.Errorf("Select() unknown group error = %v", err)
+	}
+}
+
+func TestSummarizeReport(t *testing.T) {
+	groups := []ReportGroup{
+		{SourceBranch: "dependabot/foo-1.0", PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 1, Status: "passing"},
+			{Repository: "repo-b", Number: 2, Status: "conflict"},
+			{Repository: "repo-c", Number: 3, Status: "passing"},
+		}},
+		{Key: "lodash", PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 4, Status: "checks failing"},
+		}},
+	}
+
+	got := SummarizeReport(groups)
+	want := &ReportSummary{
+		TotalGroups:       2,
+		TotalPullRequests: 4,
+		ByStatus:          map[string]int{"passing": 2, "conflict": 1, "checks failing": 1},
+		Groups: []ReportGroupSummary{
+			{Group: "dependabot/foo-1.0", Count: 3, ByStatus: map[string]int{"passing": 2, "conflict": 1}},
+			{Group: "lodash", Count: 1, ByStatus: map[string]int{"checks failing": 1}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeReport() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteReportResultHumanSummary(t *testing.T) {
+	groups := []ReportGroup{
+		{SourceBranch: "dependabot/foo-1.0", Count: 3, PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 1, Status: "conflict"},
+			{Repository: "repo-b", Number: 2, Status: "passing"},
+			{Repository: "repo-c", Number: 3, Status: "needs-rebase"},
+		}},
+	}
+	result := &ReportResult{Groups: groups, Summary: SummarizeReport(groups)}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, false, true)
+	if err := w.WriteReportResult(result, "standard"); err != nil {
+		t.Fatalf("WriteReportResult() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "dependabot/foo-1.0 (3 PRs) · 1 passing, 1 needs-rebase, 1 conflict\n") {
+		t.Errorf("expected group status breakdown, got: %s", out)
+	}
+	if !strings.HasSuffix(out, "1 groups │ 3 PRs │ 1 passing │ 1 needs-rebase │ 1 conflict\n") {
+		t.Errorf("expected summary line, got: %s", out)
+	}
+}
+
+func TestWriteReportResultJSONSummary(t *testing.T) {
+	groups := []ReportGroup{
+		{SourceBranch: "dependabot/foo-1.0", Count: 1, PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 1, Status: "passing"},
+		}},
+	}
+	result := &ReportResult{Groups: groups, Summary: SummarizeReport(groups)}
+
+	for casing, want := range map[JSONCasing]string{
+		CasingCamel: `"summary": {
+    "totalGroups": 1,
+    "totalPullRequests": 1,
+    "byStatus": {
+      "passing": 1
+    },`,
+		CasingSnake: `"summary": {
+    "total_groups": 1,
+    "total_pull_requests": 1,
+    "by_status": {
+      "passing": 1
+    },`,
+	} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, true, false)
+		w.SetJSONCasing(casing)
+		if err := w.WriteReportResult(result, "standard"); err != nil {
+			t.Fatalf("WriteReportResult() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%s JSON missing summary %s, got: %s", casing, want, buf.String())
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{14 * 24 * time.Hour, "14d"},
+		{36 * time.Hour, "1d 12h"},
+		{5 * time.Hour, "5h"},
+		{90 * time.Minute, "1h 30m"},
+		{20 * time.Second, "0m"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(tt.age); got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestWriteReportResultHumanVerboseAge(t *testing.T) {
+	end := time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+	result := &ReportResult{
+		Metadata: &ReportMetadata{EndTime: end},
+		Groups: []ReportGroup{{SourceBranch: "dependabot/foo-1.0", Count: 2, PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 1, Status: "passing", CreatedAt: end.Add(-12 * 24 * time.Hour)},
+			{Repository: "repo-b", Number: 2, Status: "checks failing", CreatedAt: end.Add(-12 * 24 * time.Hour), FailingSince: end.Add(-3 * 24 * time.Hour)},
+		}}},
+	}
+
+	for verbosity, want := range map[string]bool{"standard": false, "verbose": true} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, false, true)
+		if err := w.WriteReportResult(result, verbosity); err != nil {
+			t.Fatalf("WriteReportResult() error = %v", err)
+		}
+		out := buf.String()
+		if got := strings.Contains(out, "repo-a #1 passing open 12d\n"); got != want {
+			t.Errorf("%s: age shown = %v, want %v, got: %s", verbosity, got, want, out)
+		}
+		if got := strings.Contains(out, "checks failing open 12d, failing 3d\n"); got != want {
+			t.Errorf("%s: failing age shown = %v, want %v, got: %s", verbosity, got, want, out)
+		}
+	}
+}
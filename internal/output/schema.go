@@ -0,0 +1,125 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is written as schema_version in every JSON document. It is
+// incremented whenever a field is renamed or removed or its meaning changes;
+// adding a field does not change it.
+const SchemaVersion = 1
+
+// JSONCasing selects the key casing of the report JSON output.
+type JSONCasing string
+
+const (
+	// CasingCamel is the report's original camelCase keys (sourceBranch, pullRequests).
+	CasingCamel JSONCasing = "camel"
+	// CasingSnake uses snake_case keys, matching the run output.
+	CasingSnake JSONCasing = "snake"
+)
+
+// SchemaDocuments lists the JSON documents with a published schema.
+var SchemaDocuments = []string{"run", "report", "event"}
+
+// registeredSchema is a document added with RegisterSchema.
+type registeredSchema struct {
+	title string
+	t     reflect.Type
+}
+
+var registeredSchemas = map[string]registeredSchema{}
+
+// RegisterSchema publishes the schema of a JSON document written by a package
+// that output cannot import, such as the audit and history commands. The
+// document type must have a schema_version field. It is meant to be called
+// from the package's init function.
+func RegisterSchema(document, title string, t reflect.Type) {
+	SchemaDocuments = append(SchemaDocuments, document)
+	registeredSchemas[document] = registeredSchema{title: title, t: t}
+}
+
+// JSONSchema returns the JSON Schema for a document written by ghprmerge:
+// "run" for merge, rebase, and close with --json, "report" for report with
+// --json, "event" for each line of --format ndjson, and any document added
+// with RegisterSchema. The casing only affects the report document.
+func JSONSchema(document string, casing JSONCasing) ([]byte, error) {
+	var t reflect.Type
+	var title string
+	switch document {
+	case "run":
+		t, title = reflect.TypeFor[RunResult](), "ghprmerge run result"
+	case "report":
+		t, title = reflect.TypeFor[ReportResult](), "ghprmerge report result"
+		if casing == CasingSnake {
+			t = reflect.TypeFor[reportResultSnake]()
+		}
+	case "event":
+		t, title = reflect.TypeFor[Event](), "ghprmerge NDJSON event"
+	default:
+		registered, ok := registeredSchemas[document]
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %q: must be one of %s", document, strings.Join(SchemaDocuments, ", "))
+		}
+		t, title = registered.t, registered.title
+	}
+
+	schema := schemaFor(t)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = title
+	properties := schema["properties"].(map[string]any)
+	properties["schema_version"].(map[string]any)["const"] = SchemaVersion
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemaFor derives a JSON Schema from a Go type and its json struct tags.
+// Fields without omitempty or omitzero are required; slices and maps may be null.
+func schemaFor(t reflect.Type) map[string]any {
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem())
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice:
+		// encoding/json writes a nil slice as null.
+		return map[string]any{"type": []string{"array", "null"}, "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaFor(field.Type)
+			if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+				required = append(required, name)
+			}
+		}
+		return map[string]any{"type": "object", "properties": properties, "required": required}
+	default:
+		return map[string]any{}
+	}
+}
@@ -0,0 +1,118 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+// decodeSchema parses a schema returned by JSONSchema.
+func decodeSchema(t *testing.T, document string, casing JSONCasing) map[string]any {
+	t.Helper()
+	data, err := JSONSchema(document, casing)
+	if err != nil {
+		t.Fatalf("JSONSchema(%q) error = %v", document, err)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("JSONSchema(%q) is not valid JSON: %v", document, err)
+	}
+	return schema
+}
+
+// checkKeys reports document keys that the schema does not describe, recursing
+// into nested objects and the first element of arrays.
+func checkKeys(t *testing.T, path string, value any, schema map[string]any) {
+	t.Helper()
+	switch v := value.(type) {
+	case map[string]any:
+		properties, ok := schema["properties"].(map[string]any)
+		if !ok {
+			return // maps such as skipped_by_reason
+		}
+		for key, child := range v {
+			childSchema, ok := properties[key].(map[string]any)
+			if !ok {
+				t.Errorf("%s.%s is not in the schema", path, key)
+				continue
+			}
+			checkKeys(t, path+"."+key, child, childSchema)
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok && len(v) > 0 {
+			checkKeys(t, path+"[0]", v[0], items)
+		}
+	}
+}
+
+func TestJSONSchemaDocuments(t *testing.T) {
+	for _, document := range SchemaDocuments {
+		schema := decodeSchema(t, document, CasingCamel)
+		version := schema["properties"].(map[string]any)["schema_version"].(map[string]any)
+		if version["const"] != float64(SchemaVersion) {
+			t.Errorf("%s schema_version const = %v, want %d", document, version["const"], SchemaVersion)
+		}
+		if !slices.Contains(schema["required"].([]any), any("schema_version")) {
+			t.Errorf("%s schema does not require schema_version", document)
+		}
+	}
+
+	if _, err := JSONSchema("nope", CasingCamel); err == nil {
+		t.Error("JSONSchema() accepted an unknown document")
+	}
+}
+
+func TestJSONSchemaMatchesRunOutput(t *testing.T) {
+	result := &RunResult{
+		Repositories: []RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []PullRequestResult{
+				{Number: 1, Action: ActionSkipConflict, SkipReason: ReasonConflict, Labels: []string{"deps"}},
+			}},
+		},
+		Summary: RunSummary{SkippedByReason: map[string]int{"merge conflict": 1}},
+	}
+	var buf bytes.Buffer
+	if err := NewWriter(&buf, true, false).WriteResult(result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if doc["schema_version"] != float64(SchemaVersion) {
+		t.Errorf("schema_version = %v, want %d", doc["schema_version"], SchemaVersion)
+	}
+	checkKeys(t, "run", doc, decodeSchema(t, "run", CasingCamel))
+}
+
+func TestJSONSchemaMatchesReportOutput(t *testing.T) {
+	result := &ReportResult{
+		Metadata: &ReportMetadata{Org: "myorg", SourceBranchPrefixes: []string{"dependabot/"}},
+		Groups: []ReportGroup{{SourceBranch: "dependabot/foo", Count: 1, PullRequests: []ReportPullRequest{
+			{Repository: "repo-a", Number: 1, Status: "passing", Title: "Bump foo", URL: "https://example.com"},
+		}}},
+		Summary: &ReportSummary{TotalGroups: 1, TotalPullRequests: 1, ByStatus: map[string]int{"passing": 1},
+			Groups: []ReportGroupSummary{{Group: "dependabot/foo", Count: 1, ByStatus: map[string]int{"passing": 1}}}},
+		Comparison: &ReportComparison{
+			PreviousEndTime: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			NewGroups:       []ReportGroupChange{{Group: "dependabot/foo", Count: 1}},
+			StatusChanges:   []ReportStatusChange{{Group: "dependabot/foo", Repository: "repo-a", Number: 1, PreviousStatus: "conflict", Status: "passing"}},
+		},
+	}
+
+	for _, casing := range []JSONCasing{CasingCamel, CasingSnake} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, true, false)
+		w.SetJSONCasing(casing)
+		if err := w.WriteReportResult(result, "standard"); err != nil {
+			t.Fatalf("WriteReportResult() error = %v", err)
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		checkKeys(t, "report", doc, decodeSchema(t, "report", casing))
+	}
+}
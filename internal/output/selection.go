@@ -0,0 +1,108 @@
+package output
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// rangePattern matches a selection entry that is a number or a range.
+var rangePattern = regexp.MustCompile(`^(\d+)(?:-(\d+))?$`)
+
+// PendingRef locates a pending pull request action within a RunResult.
+type PendingRef struct {
+	Repo        int
+	PullRequest int
+}
+
+// PendingActions lists the pending actions of a result in display order.
+// The confirmation prompt numbers them from 1 in this order.
+func PendingActions(result *RunResult) []PendingRef {
+	var refs []PendingRef
+	for i, repo := range result.Repositories {
+		for j, pr := range repo.PullRequests {
+			if IsPendingAction(pr.Action) {
+				refs = append(refs, PendingRef{Repo: i, PullRequest: j})
+			}
+		}
+	}
+	return refs
+}
+
+// ParseSelection parses a comma-separated list of pending actions, such as
+// "1-10,12,myorg/api". Numbers and ranges are 1-based positions in pending;
+// any other entry names a repository, by name or full name, and selects all of
+// its pending actions.
+func ParseSelection(input string, result *RunResult, pending []PendingRef) ([]PendingRef, error) {
+	selected := make(map[PendingRef]bool)
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		if first, last, ok := parseRange(field); ok {
+			if first < 1 || last > len(pending) || first > last {
+				return nil, fmt.Errorf("%q is out of range; choose between 1 and %d", field, len(pending))
+			}
+			for n := first; n <= last; n++ {
+				selected[pending[n-1]] = true
+			}
+			continue
+		}
+
+		found := false
+		for _, ref := range pending {
+			repo := result.Repositories[ref.Repo]
+			if strings.EqualFold(repo.Name, field) || strings.EqualFold(repo.FullName, field) {
+				selected[ref] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%q is not a number, range, or repository with pending actions", field)
+		}
+	}
+
+	var refs []PendingRef
+	for _, ref := range pending {
+		if selected[ref] {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// parseRange parses "n" or "n-m". It reports false for anything else, so that
+// repository names starting with a digit, such as 3d-viewer, are matched as
+// names. A number too large to parse is returned as 0, which is out of range.
+func parseRange(field string) (int, int, bool) {
+	match := rangePattern.FindStringSubmatch(field)
+	if match == nil {
+		return 0, 0, false
+	}
+	first, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, 0, true
+	}
+	if match[2] == "" {
+		return first, first, true
+	}
+	last, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, 0, true
+	}
+	return first, last, true
+}
+
+// Deselect marks pending actions as skipped by the user so they are reported
+// but not executed.
+func Deselect(result *RunResult, refs []PendingRef) {
+	for _, ref := range refs {
+		pr := &result.Repositories[ref.Repo].PullRequests[ref.PullRequest]
+		pr.Reason = fmt.Sprintf("deselected at confirmation prompt (was %s)", pr.Action)
+		pr.Action = ActionSkipDeselected
+		pr.SkipReason = ReasonDeselected
+	}
+}
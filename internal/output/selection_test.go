@@ -0,0 +1,107 @@
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func selectionResult() *RunResult {
+	return &RunResult{
+		Repositories: []RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []PullRequestResult{
+				{Number: 1, Action: ActionWouldMerge},
+				{Number: 2, Action: ActionSkipConflict},
+				{Number: 3, Action: ActionWouldRebase},
+			}},
+			{Name: "web", FullName: "myorg/web", PullRequests: []PullRequestResult{
+				{Number: 4, Action: ActionWouldMerge},
+				{Number: 5, Action: ActionWouldClose},
+			}},
+		},
+	}
+}
+
+func TestPendingActions(t *testing.T) {
+	got := PendingActions(selectionResult())
+	want := []PendingRef{{0, 0}, {0, 2}, {1, 0}, {1, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PendingActions() = %v, want %v", got, want)
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []PendingRef
+		wantErr string
+	}{
+		{name: "empty keeps all", input: "\n", want: nil},
+		{name: "single numbers", input: "1,4", want: []PendingRef{{0, 0}, {1, 1}}},
+		{name: "range", input: "2-3", want: []PendingRef{{0, 2}, {1, 0}}},
+		{name: "range and number with spaces", input: " 1-2 , 4 ", want: []PendingRef{{0, 0}, {0, 2}, {1, 1}}},
+		{name: "repository name", input: "web", want: []PendingRef{{1, 0}, {1, 1}}},
+		{name: "repository full name", input: "myorg/api", want: []PendingRef{{0, 0}, {0, 2}}},
+		{name: "overlapping entries", input: "1-3,api", want: []PendingRef{{0, 0}, {0, 2}, {1, 0}}},
+		{name: "out of range", input: "5", wantErr: "out of range"},
+		{name: "reversed range", input: "3-1", wantErr: "out of range"},
+		{name: "bad range", input: "1-x", wantErr: "not a number, range, or repository"},
+		{name: "unknown repository", input: "cli", wantErr: "not a number, range, or repository"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := selectionResult()
+			got, err := ParseSelection(tt.input, result, PendingActions(result))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSelection(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelection(%q) error = %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSelection(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSelectionDigitRepositoryName(t *testing.T) {
+	result := selectionResult()
+	result.Repositories = append(result.Repositories, RepositoryResult{Name: "3d-viewer", FullName: "myorg/3d-viewer",
+		PullRequests: []PullRequestResult{{Number: 6, Action: ActionWouldMerge}}})
+	pending := PendingActions(result)
+
+	for _, input := range []string{"3d-viewer", "myorg/3d-viewer"} {
+		got, err := ParseSelection(input, result, pending)
+		if err != nil {
+			t.Fatalf("ParseSelection(%q) error = %v", input, err)
+		}
+		if want := []PendingRef{{2, 0}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("ParseSelection(%q) = %v, want %v", input, got, want)
+		}
+	}
+	if _, err := ParseSelection("99999999999999999999", result, pending); err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("ParseSelection(huge number) error = %v, want out of range", err)
+	}
+}
+
+func TestDeselect(t *testing.T) {
+	result := selectionResult()
+	Deselect(result, []PendingRef{{1, 0}})
+
+	pr := result.Repositories[1].PullRequests[0]
+	if pr.Action != ActionSkipDeselected || pr.SkipReason != ReasonDeselected {
+		t.Errorf("deselected PR = (%q, %q), want (%q, %q)", pr.Action, pr.SkipReason, ActionSkipDeselected, ReasonDeselected)
+	}
+	if !strings.Contains(pr.Reason, "would merge") {
+		t.Errorf("Reason = %q, want it to name the deselected action", pr.Reason)
+	}
+	if len(PendingActions(result)) != 3 {
+		t.Errorf("PendingActions() after Deselect = %d, want 3", len(PendingActions(result)))
+	}
+}
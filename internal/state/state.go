@@ -0,0 +1,222 @@
+// Package state checkpoints the progress of a run to a local file so an
+// interrupted run can be resumed without re-evaluating finished repositories.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// Version is the version of the state file format.
+const Version = 1
+
+// PullRequestState records a PR of a repository that has not completed yet.
+// A PR is in flight from just before it is processed until its result is
+// recorded, so an in-flight PR may have had its action applied or not.
+type PullRequestState struct {
+	Number   int                       `json:"number"`
+	InFlight bool                      `json:"in_flight,omitempty"`
+	Result   *output.PullRequestResult `json:"result,omitempty"`
+}
+
+// RepositoryState records the progress of one repository. Once the repository
+// is completed its result is kept and the per-PR records are dropped.
+type RepositoryState struct {
+	Completed    bool                     `json:"completed,omitempty"`
+	Result       *output.RepositoryResult `json:"result,omitempty"`
+	PullRequests []PullRequestState       `json:"pull_requests,omitempty"`
+}
+
+// State is the checkpoint of a run. Its methods are safe to call on a nil
+// State, which records nothing. Every change is written to the file at once;
+// the first write error is kept and reported by Err.
+type State struct {
+	Version        int                         `json:"version"`
+	Command        string                      `json:"command"`
+	Org            string                      `json:"org"`
+	SourceBranches []string                    `json:"source_branches"`
+	StartTime      time.Time                   `json:"start_time"`
+	UpdateTime     time.Time                   `json:"update_time"`
+	Repositories   map[string]*RepositoryState `json:"repositories"`
+
+	path string
+	mu   sync.Mutex
+	err  error
+}
+
+// New returns an empty state for a run that checkpoints to path.
+func New(path, command, org string, sourceBranches []string) *State {
+	return &State{
+		Version:        Version,
+		Command:        command,
+		Org:            org,
+		SourceBranches: sourceBranches,
+		StartTime:      time.Now(),
+		Repositories:   make(map[string]*RepositoryState),
+		path:           path,
+	}
+}
+
+// Load reads the state file at path.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	s := &State{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if s.Version != Version {
+		return nil, fmt.Errorf("state file %s has version %d, want %d", path, s.Version, Version)
+	}
+	if s.Repositories == nil {
+		s.Repositories = make(map[string]*RepositoryState)
+	}
+	s.path = path
+	return s, nil
+}
+
+// Matches reports an error when the state was written by a different run, so
+// it is not resumed with another command, organization, or source branch.
+func (s *State) Matches(command, org string, sourceBranches []string) error {
+	if s.Command != command || s.Org != org || !slices.Equal(s.SourceBranches, sourceBranches) {
+		return fmt.Errorf("state file %s was written by %q for --org %s --source-branch %v; run without --resume to start over",
+			s.path, s.Command, s.Org, s.SourceBranches)
+	}
+	return nil
+}
+
+// Save writes the state file. The file is replaced atomically so an
+// interrupted write never leaves a truncated checkpoint behind.
+func (s *State) Save() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save()
+}
+
+// Err returns the first error from writing the state file.
+func (s *State) Err() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Completed returns the recorded result of a completed repository.
+func (s *State) Completed(fullName string) (output.RepositoryResult, bool) {
+	if s == nil {
+		return output.RepositoryResult{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo := s.Repositories[fullName]
+	if repo == nil || !repo.Completed || repo.Result == nil {
+		return output.RepositoryResult{}, false
+	}
+	return *repo.Result, true
+}
+
+// PullRequests returns the PRs recorded for a repository that has not completed.
+func (s *State) PullRequests(fullName string) []PullRequestState {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if repo := s.Repositories[fullName]; repo != nil {
+		return slices.Clone(repo.PullRequests)
+	}
+	return nil
+}
+
+// StartPullRequest marks a PR as in flight before it is processed.
+func (s *State) StartPullRequest(fullName string, number int) {
+	s.update(fullName, func(repo *RepositoryState) {
+		pr := repo.pullRequest(number)
+		pr.InFlight = true
+	})
+}
+
+// FinishPullRequest records the result of a processed PR.
+func (s *State) FinishPullRequest(fullName string, result output.PullRequestResult) {
+	s.update(fullName, func(repo *RepositoryState) {
+		pr := repo.pullRequest(result.Number)
+		pr.InFlight = false
+		pr.Result = &result
+	})
+}
+
+// CompleteRepository records the final result of a repository.
+func (s *State) CompleteRepository(result output.RepositoryResult) {
+	s.update(result.FullName, func(repo *RepositoryState) {
+		repo.Completed = true
+		repo.Result = &result
+		repo.PullRequests = nil
+	})
+}
+
+// update applies a change to a repository's state and saves the file.
+func (s *State) update(fullName string, change func(*RepositoryState)) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repo := s.Repositories[fullName]
+	if repo == nil {
+		repo = &RepositoryState{}
+		s.Repositories[fullName] = repo
+	}
+	change(repo)
+	if s.err == nil {
+		s.err = s.save()
+	}
+}
+
+// pullRequest returns the record of a PR, adding it when missing.
+func (r *RepositoryState) pullRequest(number int) *PullRequestState {
+	for i := range r.PullRequests {
+		if r.PullRequests[i].Number == number {
+			return &r.PullRequests[i]
+		}
+	}
+	r.PullRequests = append(r.PullRequests, PullRequestState{Number: number})
+	return &r.PullRequests[len(r.PullRequests)-1]
+}
+
+func (s *State) save() error {
+	s.UpdateTime = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
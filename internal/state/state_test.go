@@ -0,0 +1,76 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sweep.json")
+	s := New(path, "merge", "myorg", []string{"deps/"})
+	s.StartPullRequest("myorg/a", 1)
+	s.FinishPullRequest("myorg/a", output.PullRequestResult{Number: 1, Action: output.ActionMerged})
+	s.StartPullRequest("myorg/a", 2)
+	s.CompleteRepository(output.RepositoryResult{FullName: "myorg/b", PullRequests: []output.PullRequestResult{{Number: 3, Action: output.ActionRebased}}})
+	if err := s.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := loaded.Matches("merge", "myorg", []string{"deps/"}); err != nil {
+		t.Errorf("Matches() error = %v", err)
+	}
+	if err := loaded.Matches("close", "myorg", []string{"deps/"}); err == nil {
+		t.Error("Matches() accepted a different command")
+	}
+
+	if _, ok := loaded.Completed("myorg/a"); ok {
+		t.Error("Completed(myorg/a) = true, want false")
+	}
+	b, ok := loaded.Completed("myorg/b")
+	if !ok || len(b.PullRequests) != 1 || b.PullRequests[0].Action != output.ActionRebased {
+		t.Errorf("Completed(myorg/b) = %+v, %v", b, ok)
+	}
+
+	prs := loaded.PullRequests("myorg/a")
+	if len(prs) != 2 {
+		t.Fatalf("PullRequests(myorg/a) = %+v, want 2", prs)
+	}
+	if prs[0].InFlight || prs[0].Result == nil || prs[0].Result.Action != output.ActionMerged {
+		t.Errorf("PR #1 = %+v, want finished as merged", prs[0])
+	}
+	if !prs[1].InFlight || prs[1].Result != nil {
+		t.Errorf("PR #2 = %+v, want in flight", prs[1])
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the state file", len(entries))
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestNilState(t *testing.T) {
+	var s *State
+	s.StartPullRequest("myorg/a", 1)
+	s.CompleteRepository(output.RepositoryResult{FullName: "myorg/a"})
+	if _, ok := s.Completed("myorg/a"); ok {
+		t.Error("Completed() on nil state = true")
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() on nil state = %v", err)
+	}
+}
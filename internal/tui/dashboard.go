@@ -0,0 +1,387 @@
+// Package tui implements the full-screen dashboard shown with --tui.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+// Executor performs actions on behalf of the dashboard. *merger.Merger implements it.
+type Executor interface {
+	// ExecutePullRequest executes the planned action of a PR from the scan result.
+	ExecutePullRequest(ctx context.Context, repo output.RepositoryResult, pr *output.PullRequestResult)
+
+	// RefreshPullRequest evaluates a PR again against its current state on GitHub.
+	RefreshPullRequest(ctx context.Context, repo output.RepositoryResult, number int) (output.PullRequestResult, error)
+}
+
+// Key names for keys that are not single printable characters.
+const (
+	KeyUp     = "up"
+	KeyDown   = "down"
+	KeyPgUp   = "pgup"
+	KeyPgDown = "pgdown"
+	KeyHome   = "home"
+	KeyEnd    = "end"
+	KeyEnter  = "enter"
+	KeyCtrlC  = "ctrl-c"
+)
+
+// Filter names that are not a single action.
+const (
+	FilterAll       = "all"
+	FilterPending   = "pending"
+	FilterCompleted = "completed"
+)
+
+// keyAction describes the pending actions a dashboard key executes.
+type keyAction struct {
+	verb    string
+	actions []output.Action
+}
+
+// keyActions maps action keys to the pending actions they run. A key only acts
+// on a PR whose scan planned one of its actions, so the dashboard never skips
+// the safety checks that decided the plan.
+var keyActions = map[string]keyAction{
+	"m": {verb: "merge", actions: []output.Action{output.ActionWouldMerge}},
+	"r": {verb: "rebase", actions: []output.Action{output.ActionWouldRebase, output.ActionWouldRecreate}},
+	"c": {verb: "close", actions: []output.Action{output.ActionWouldClose, output.ActionWouldIgnore}},
+}
+
+// row locates a PR within the result.
+type row struct {
+	repo int
+	pr   int
+}
+
+// Dashboard holds the state of the dashboard. It is independent of the
+// terminal so that it can be driven by key names in tests.
+type Dashboard struct {
+	result  *output.RunResult
+	exec    Executor
+	console *output.Console
+	openURL func(string) error
+
+	filter  string
+	cursor  int
+	offset  int
+	status  string
+	confirm string        // action key awaiting y/N confirmation
+	work    func() func() // slow work to start with the next redraw; returns the update to apply
+	busy    bool          // work is running
+	height  int           // rows available for the table in the last render
+	done    bool
+}
+
+// New creates a dashboard over a scan result.
+func New(result *output.RunResult, exec Executor, console *output.Console, openURL func(string) error) *Dashboard {
+	return &Dashboard{
+		result:  result,
+		exec:    exec,
+		console: console,
+		openURL: openURL,
+		filter:  FilterAll,
+		height:  10,
+	}
+}
+
+// Done reports whether the user has quit.
+func (d *Dashboard) Done() bool {
+	return d.done
+}
+
+// filters lists the available filters: all, pending, completed, then every
+// action present in the result in order of first appearance.
+func (d *Dashboard) filters() []string {
+	names := []string{FilterAll, FilterPending, FilterCompleted}
+	for _, repo := range d.result.Repositories {
+		for _, pr := range repo.PullRequests {
+			if !slices.Contains(names, string(pr.Action)) {
+				names = append(names, string(pr.Action))
+			}
+		}
+	}
+	return names
+}
+
+// matches reports whether an action passes the current filter.
+func (d *Dashboard) matches(action output.Action) bool {
+	switch d.filter {
+	case FilterAll:
+		return true
+	case FilterPending:
+		return output.IsPendingAction(action)
+	case FilterCompleted:
+		return output.IsCompletedAction(action)
+	default:
+		return string(action) == d.filter
+	}
+}
+
+// rows lists the PRs that pass the current filter.
+func (d *Dashboard) rows() []row {
+	var rows []row
+	for i, repo := range d.result.Repositories {
+		for j, pr := range repo.PullRequests {
+			if d.matches(pr.Action) {
+				rows = append(rows, row{repo: i, pr: j})
+			}
+		}
+	}
+	return rows
+}
+
+// selected returns the highlighted PR and its repository, or nil if the table is empty.
+func (d *Dashboard) selected() (*output.RepositoryResult, *output.PullRequestResult) {
+	rows := d.rows()
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	d.cursor = min(max(d.cursor, 0), len(rows)-1)
+	r := rows[d.cursor]
+	repo := &d.result.Repositories[r.repo]
+	return repo, &repo.PullRequests[r.pr]
+}
+
+// HandleKey applies a key press.
+func (d *Dashboard) HandleKey(ctx context.Context, key string) {
+	if d.confirm != "" {
+		d.handleConfirm(ctx, key)
+		return
+	}
+
+	switch key {
+	case "q", KeyCtrlC:
+		d.done = true
+		if d.busy {
+			d.status = "Quitting when the current action finishes..."
+		}
+	case KeyUp, "k":
+		d.cursor--
+	case KeyDown, "j":
+		d.cursor++
+	case KeyPgUp:
+		d.cursor -= d.height
+	case KeyPgDown:
+		d.cursor += d.height
+	case KeyHome, "g":
+		d.cursor = 0
+	case KeyEnd, "G":
+		d.cursor = len(d.rows()) - 1
+	case "f", "F":
+		d.cycleFilter(key == "f")
+	case "o", KeyEnter:
+		d.open()
+	case "u", "m", "r", "c":
+		if d.busy {
+			d.status = "Wait for the current action to finish"
+			break
+		}
+		if key == "u" {
+			d.refresh(ctx)
+		} else {
+			d.requestAction(key)
+		}
+	}
+	d.cursor = min(max(d.cursor, 0), max(len(d.rows())-1, 0))
+}
+
+// cycleFilter moves to the next or previous filter.
+func (d *Dashboard) cycleFilter(forward bool) {
+	names := d.filters()
+	i := slices.Index(names, d.filter)
+	if forward {
+		i = (i + 1) % len(names)
+	} else {
+		i = (i - 1 + len(names)) % len(names)
+	}
+	d.filter = names[i]
+	d.cursor, d.offset = 0, 0
+	d.status = fmt.Sprintf("Filter: %s", d.filter)
+}
+
+// open opens the highlighted PR in the browser.
+func (d *Dashboard) open() {
+	_, pr := d.selected()
+	if pr == nil {
+		return
+	}
+	if err := d.openURL(pr.URL); err != nil {
+		d.status = fmt.Sprintf("Could not open %s: %v", pr.URL, err)
+		return
+	}
+	d.status = fmt.Sprintf("Opened %s", pr.URL)
+}
+
+// refresh evaluates the highlighted PR again.
+func (d *Dashboard) refresh(ctx context.Context) {
+	repo, pr := d.selected()
+	if pr == nil {
+		return
+	}
+	d.status = fmt.Sprintf("Refreshing %s #%d...", repo.FullName, pr.Number)
+	d.work = func() func() {
+		refreshed, err := d.exec.RefreshPullRequest(ctx, *repo, pr.Number)
+		return func() {
+			if err != nil {
+				d.status = fmt.Sprintf("Refresh of %s #%d failed: %v", repo.FullName, pr.Number, err)
+				return
+			}
+			*pr = refreshed
+			d.status = fmt.Sprintf("%s #%d: %s", repo.FullName, pr.Number, pr.Action)
+		}
+	}
+}
+
+// requestAction asks for confirmation before running an action key.
+func (d *Dashboard) requestAction(key string) {
+	repo, pr := d.selected()
+	if pr == nil {
+		return
+	}
+	ka := keyActions[key]
+	if !slices.Contains(ka.actions, pr.Action) {
+		d.status = fmt.Sprintf("%s #%d has no pending %s (%s)", repo.FullName, pr.Number, ka.verb, pr.Action)
+		return
+	}
+	d.confirm = key
+	d.status = fmt.Sprintf("Run %q on %s #%d? [y/N]", pr.Action, repo.FullName, pr.Number)
+}
+
+// handleConfirm runs or cancels the action awaiting confirmation.
+func (d *Dashboard) handleConfirm(ctx context.Context, key string) {
+	d.confirm = ""
+	if key != "y" && key != "Y" {
+		d.status = "Cancelled"
+		return
+	}
+	repo, pr := d.selected()
+	if pr == nil {
+		return
+	}
+	d.status = fmt.Sprintf("Running %q on %s #%d...", pr.Action, repo.FullName, pr.Number)
+	d.work = func() func() {
+		// Execute on a copy so the screen can be drawn from the result meanwhile.
+		updated := *pr
+		updated.Labels = slices.Clone(pr.Labels)
+		d.exec.ExecutePullRequest(ctx, *repo, &updated)
+		return func() {
+			*pr = updated
+			d.status = fmt.Sprintf("%s #%d: %s", repo.FullName, pr.Number, pr.Action)
+			if pr.Reason != "" {
+				d.status += " - " + pr.Reason
+			}
+		}
+	}
+}
+
+// takeWork returns the slow work queued by the last key, or nil. The work may
+// run on another goroutine; the dashboard stays busy until the update it
+// returns is passed to finishWork.
+func (d *Dashboard) takeWork() func() func() {
+	work := d.work
+	d.work = nil
+	if work != nil {
+		d.busy = true
+	}
+	return work
+}
+
+// finishWork applies the update returned by work from takeWork.
+func (d *Dashboard) finishWork(update func()) {
+	d.busy = false
+	update()
+}
+
+// runWork runs slow work queued by the last key, if any, and applies its update.
+func (d *Dashboard) runWork() {
+	if work := d.takeWork(); work != nil {
+		d.finishWork(work())
+	}
+}
+
+// Render returns the screen contents for a terminal of the given size.
+func (d *Dashboard) Render(width, height int) []string {
+	width = max(width, 40)
+	height = max(height, 8)
+	rows := d.rows()
+
+	// Header, column titles, table, separator, two detail lines, status, help.
+	d.height = max(height-7, 1)
+	d.cursor = min(max(d.cursor, 0), max(len(rows)-1, 0))
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	}
+	if d.cursor >= d.offset+d.height {
+		d.offset = d.cursor - d.height + 1
+	}
+
+	repoWidth := len("REPOSITORY")
+	for _, r := range rows {
+		repoWidth = max(repoWidth, utf8.RuneCountInString(d.result.Repositories[r.repo].FullName))
+	}
+	repoWidth = min(repoWidth, width/4)
+	const numberWidth, actionWidth = 6, 34
+	titleWidth := max(width-repoWidth-numberWidth-actionWidth-8, 10)
+
+	lines := make([]string, 0, height)
+	title := fmt.Sprintf("ghprmerge %s - %s - filter: %s - %d of %d PRs",
+		d.result.Metadata.Mode, d.result.Metadata.Org, d.filter, len(rows), d.total())
+	lines = append(lines, d.console.Bold(fit(title, width)))
+	lines = append(lines, d.console.Dim(fmt.Sprintf("    %s  %s  %s  %s",
+		fit("REPOSITORY", repoWidth), fit("PR", numberWidth), fit("TITLE", titleWidth), "ACTION")))
+
+	for i := d.offset; i < d.offset+d.height; i++ {
+		if i >= len(rows) {
+			lines = append(lines, "")
+			continue
+		}
+		repo := d.result.Repositories[rows[i].repo]
+		pr := repo.PullRequests[rows[i].pr]
+		symbol, action := d.console.StyledAction(pr.Action)
+		marker := " "
+		if i == d.cursor {
+			marker = d.console.Cyan(">")
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s  %s  %s  %s", marker, symbol,
+			fit(repo.FullName, repoWidth), fit(fmt.Sprintf("#%d", pr.Number), numberWidth), fit(pr.Title, titleWidth), action))
+	}
+
+	lines = append(lines, d.console.Dim(strings.Repeat("─", width)))
+	if repo, pr := d.selected(); pr != nil {
+		lines = append(lines, fit(fmt.Sprintf("%s #%d  %s", repo.FullName, pr.Number, pr.URL), width))
+		lines = append(lines, d.console.Dim(fit(pr.Reason, width)))
+	} else {
+		lines = append(lines, d.console.Dim("No pull requests match this filter."), "")
+	}
+	lines = append(lines, d.console.Yellow(fit(d.status, width)))
+	lines = append(lines, d.console.Dim(fit("↑/↓ move  f/F filter  o open  m merge  r rebase  c close  u refresh  q quit", width)))
+	return lines
+}
+
+// total returns the number of PRs in the result.
+func (d *Dashboard) total() int {
+	n := 0
+	for _, repo := range d.result.Repositories {
+		n += len(repo.PullRequests)
+	}
+	return n
+}
+
+// fit pads or truncates s to exactly n runes.
+func fit(s string, n int) string {
+	count := utf8.RuneCountInString(s)
+	if count <= n {
+		return s + strings.Repeat(" ", n-count)
+	}
+	if n <= 1 {
+		return string([]rune(s)[:n])
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
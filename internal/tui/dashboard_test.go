@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+)
+
+type fakeExecutor struct {
+	executed  []int
+	refreshed output.PullRequestResult
+	err       error
+}
+
+func (f *fakeExecutor) ExecutePullRequest(ctx context.Context, repo output.RepositoryResult, pr *output.PullRequestResult) {
+	f.executed = append(f.executed, pr.Number)
+	pr.Action = output.ActionMerged
+	pr.Reason = "successfully merged"
+}
+
+func (f *fakeExecutor) RefreshPullRequest(ctx context.Context, repo output.RepositoryResult, number int) (output.PullRequestResult, error) {
+	return f.refreshed, f.err
+}
+
+func testDashboard(exec Executor, openURL func(string) error) *Dashboard {
+	result := &output.RunResult{
+		Metadata: output.RunMetadata{Org: "myorg", Mode: "merge mode"},
+		Repositories: []output.RepositoryResult{
+			{Name: "api", FullName: "myorg/api", PullRequests: []output.PullRequestResult{
+				{Number: 1, Title: "Bump a", URL: "https://github.com/myorg/api/pull/1", Action: output.ActionWouldMerge},
+				{Number: 2, Title: "Bump b", URL: "https://github.com/myorg/api/pull/2", Action: output.ActionSkipChecksFailing, Reason: "1 check failing"},
+			}},
+			{Name: "web", FullName: "myorg/web", PullRequests: []output.PullRequestResult{
+				{Number: 3, Title: "Bump c", URL: "https://github.com/myorg/web/pull/3", Action: output.ActionWouldMerge},
+			}},
+		},
+	}
+	return New(result, exec, output.NewConsole(io.Discard, true, false, true), openURL)
+}
+
+func press(d *Dashboard, keys ...string) {
+	for _, key := range keys {
+		d.HandleKey(context.Background(), key)
+		d.runWork()
+	}
+}
+
+func TestDashboardFilters(t *testing.T) {
+	d := testDashboard(&fakeExecutor{}, nil)
+
+	want := []string{FilterAll, FilterPending, FilterCompleted, string(output.ActionWouldMerge), string(output.ActionSkipChecksFailing)}
+	if got := d.filters(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("filters() = %v, want %v", got, want)
+	}
+
+	press(d, "f")
+	if d.filter != FilterPending || len(d.rows()) != 2 {
+		t.Errorf("after f: filter = %q with %d rows, want pending with 2", d.filter, len(d.rows()))
+	}
+	press(d, "F", "F")
+	if d.filter != string(output.ActionSkipChecksFailing) || len(d.rows()) != 1 {
+		t.Errorf("after F F: filter = %q with %d rows, want checks failing with 1", d.filter, len(d.rows()))
+	}
+}
+
+func TestDashboardCursorStaysInRange(t *testing.T) {
+	d := testDashboard(&fakeExecutor{}, nil)
+	press(d, "k")
+	if d.cursor != 0 {
+		t.Errorf("cursor after up at top = %d, want 0", d.cursor)
+	}
+	press(d, "j", "j", "j", "j")
+	if d.cursor != 2 {
+		t.Errorf("cursor after moving past the end = %d, want 2", d.cursor)
+	}
+	press(d, KeyHome)
+	if d.cursor != 0 {
+		t.Errorf("cursor after home = %d, want 0", d.cursor)
+	}
+}
+
+func TestDashboardActionRequiresConfirmation(t *testing.T) {
+	exec := &fakeExecutor{}
+	d := testDashboard(exec, nil)
+
+	press(d, "m", "n")
+	if len(exec.executed) != 0 || d.status != "Cancelled" {
+		t.Fatalf("after m n: executed = %v, status = %q, want cancelled", exec.executed, d.status)
+	}
+
+	press(d, "m", "y")
+	if !reflect.DeepEqual(exec.executed, []int{1}) {
+		t.Fatalf("executed = %v, want [1]", exec.executed)
+	}
+	if got := d.result.Repositories[0].PullRequests[0].Action; got != output.ActionMerged {
+		t.Errorf("Action = %q, want %q", got, output.ActionMerged)
+	}
+	if !strings.Contains(d.status, "merged") {
+		t.Errorf("status = %q, want result of the merge", d.status)
+	}
+}
+
+func TestDashboardActionOnlyRunsPlannedAction(t *testing.T) {
+	exec := &fakeExecutor{}
+	d := testDashboard(exec, nil)
+
+	press(d, "j", "m", "y")
+	if len(exec.executed) != 0 {
+		t.Fatalf("executed = %v, want nothing for a skipped PR", exec.executed)
+	}
+	if !strings.Contains(d.status, "has no pending merge") {
+		t.Errorf("status = %q, want explanation", d.status)
+	}
+
+	press(d, "k", "c")
+	if d.confirm != "" || !strings.Contains(d.status, "has no pending close") {
+		t.Errorf("close on a would-merge PR: confirm = %q, status = %q, want refusal", d.confirm, d.status)
+	}
+}
+
+func TestDashboardBusyWhileWorkRuns(t *testing.T) {
+	exec := &fakeExecutor{}
+	d := testDashboard(exec, nil)
+	ctx := context.Background()
+
+	d.HandleKey(ctx, "m")
+	d.HandleKey(ctx, "y")
+	work := d.takeWork()
+	if work == nil {
+		t.Fatal("confirmed merge queued no work")
+	}
+
+	// Keys are still handled while the work runs, but start no other action.
+	d.HandleKey(ctx, "j")
+	d.HandleKey(ctx, "m")
+	if d.cursor != 1 || d.confirm != "" || !strings.Contains(d.status, "Wait for the current action") {
+		t.Errorf("while busy: cursor = %d, confirm = %q, status = %q", d.cursor, d.confirm, d.status)
+	}
+	d.HandleKey(ctx, "q")
+	if !strings.Contains(d.status, "Quitting when the current action finishes") {
+		t.Errorf("quit while busy: status = %q", d.status)
+	}
+
+	update := work()
+	pr := &d.result.Repositories[0].PullRequests[0]
+	if pr.Action != output.ActionWouldMerge {
+		t.Errorf("Action before the update is applied = %q, want %q", pr.Action, output.ActionWouldMerge)
+	}
+	d.finishWork(update)
+	if pr.Action != output.ActionMerged || d.busy {
+		t.Errorf("after finishWork: Action = %q, busy = %v", pr.Action, d.busy)
+	}
+}
+
+func TestDashboardRefresh(t *testing.T) {
+	exec := &fakeExecutor{refreshed: output.PullRequestResult{Number: 2, Title: "Bump b", Action: output.ActionWouldMerge}}
+	d := testDashboard(exec, nil)
+
+	press(d, "j", "u")
+	if got := d.result.Repositories[0].PullRequests[1].Action; got != output.ActionWouldMerge {
+		t.Errorf("Action after refresh = %q, want %q", got, output.ActionWouldMerge)
+	}
+
+	exec.err = errors.New("boom")
+	press(d, "u")
+	if !strings.Contains(d.status, "failed: boom") {
+		t.Errorf("status = %q, want refresh failure", d.status)
+	}
+}
+
+func TestDashboardOpen(t *testing.T) {
+	var opened string
+	d := testDashboard(&fakeExecutor{}, func(url string) error {
+		opened = url
+		return nil
+	})
+
+	press(d, "j", "j", KeyEnter)
+	if opened != "https://github.com/myorg/web/pull/3" {
+		t.Errorf("opened = %q, want PR #3 URL", opened)
+	}
+}
+
+func TestDashboardRender(t *testing.T) {
+	d := testDashboard(&fakeExecutor{}, nil)
+	lines := d.Render(100, 12)
+
+	if len(lines) != 12 {
+		t.Fatalf("Render() returned %d lines, want 12", len(lines))
+	}
+	screen := strings.Join(lines, "\n")
+	for _, want := range []string{"merge mode", "3 of 3 PRs", "> ✓ myorg/api", "#2", "skip: checks failing", "https://github.com/myorg/api/pull/1", "q quit"} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Render() missing %q:\n%s", want, screen)
+		}
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[Bq\r\x1b[5~\x03"))
+	want := []string{"j", KeyDown, "q", KeyEnter, KeyPgUp, KeyCtrlC}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestFit(t *testing.T) {
+	if got := fit("abc", 5); got != "abc  " {
+		t.Errorf("fit pad = %q", got)
+	}
+	if got := fit("abcdef", 4); got != "abc…" {
+		t.Errorf("fit truncate = %q", got)
+	}
+}
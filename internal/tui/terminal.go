@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// resizePollInterval is how often the terminal size is checked for changes.
+const resizePollInterval = 250 * time.Millisecond
+
+// Run shows the dashboard full screen until the user quits. Keys are read
+// from in, which must be a terminal; the screen is drawn on out.
+func Run(ctx context.Context, d *Dashboard, in, out *os.File) error {
+	inFd, outFd := int(in.Fd()), int(out.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(outFd) {
+		return fmt.Errorf("--tui requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw terminal mode: %w", err)
+	}
+	defer term.Restore(inFd, state)
+
+	// Use the alternate screen so the scan output is intact after quitting.
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	// The reader goroutine stays blocked on stdin after the dashboard exits;
+	// the process ends shortly after, so it is not stopped.
+	keys := make(chan string)
+	go readKeys(in, keys)
+
+	ticker := time.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+
+	// Merges and other API calls run on their own goroutine so keys, resizes,
+	// and interrupts are handled meanwhile. Their update to the result is
+	// applied here, and always before returning, so a finished action is never
+	// missing from the result written after the dashboard exits.
+	var workDone chan func()
+	wait := func() {
+		if workDone != nil {
+			d.finishWork(<-workDone)
+			workDone = nil
+		}
+	}
+
+	width, height := terminalSize(outFd)
+	draw(out, d.Render(width, height))
+	for !d.Done() || workDone != nil {
+		select {
+		case <-ctx.Done():
+			// The work uses ctx too, so it returns promptly.
+			wait()
+			return ctx.Err()
+		case key, ok := <-keys:
+			if !ok {
+				wait()
+				return nil
+			}
+			d.HandleKey(ctx, key)
+		case update := <-workDone:
+			d.finishWork(update)
+			workDone = nil
+		case <-ticker.C:
+			w, h := terminalSize(outFd)
+			if w == width && h == height {
+				continue
+			}
+		}
+		width, height = terminalSize(outFd)
+		if work := d.takeWork(); work != nil {
+			workDone = make(chan func(), 1)
+			go func(done chan<- func()) { done <- work() }(workDone)
+		}
+		draw(out, d.Render(width, height))
+	}
+	return nil
+}
+
+// terminalSize returns the size of the terminal, defaulting to 80x24.
+func terminalSize(fd int) (int, int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// draw redraws the screen in place, clearing leftovers from longer lines.
+func draw(w io.Writer, lines []string) {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+	}
+	b.WriteString("\x1b[J")
+	fmt.Fprint(w, b.String())
+}
+
+// readKeys reads key presses from a raw terminal and sends their names.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// escapeKeys maps terminal escape sequences to key names.
+var escapeKeys = map[string]string{
+	"\x1b[A":  KeyUp,
+	"\x1b[B":  KeyDown,
+	"\x1b[H":  KeyHome,
+	"\x1b[F":  KeyEnd,
+	"\x1b[1~": KeyHome,
+	"\x1b[4~": KeyEnd,
+	"\x1b[5~": KeyPgUp,
+	"\x1b[6~": KeyPgDown,
+	"\x1bOA":  KeyUp,
+	"\x1bOB":  KeyDown,
+}
+
+// parseKeys splits one read from the terminal into key names. Unknown escape
+// sequences are dropped.
+func parseKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		switch b[0] {
+		case 0x1b:
+			matched := false
+			for seq, key := range escapeKeys {
+				if strings.HasPrefix(string(b), seq) {
+					keys = append(keys, key)
+					b = b[len(seq):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				// Drop the rest of an unrecognized sequence.
+				return keys
+			}
+		case 0x03:
+			keys = append(keys, KeyCtrlC)
+			b = b[1:]
+		case '\r', '\n':
+			keys = append(keys, KeyEnter)
+			b = b[1:]
+		default:
+			keys = append(keys, string(b[0]))
+			b = b[1:]
+		}
+	}
+	return keys
+}
+
+// OpenBrowser opens a URL in the user's default browser.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -5,19 +5,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/audit"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/config"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/github"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/history"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/merger"
 	"github.com/UnitVectorY-Labs/ghprmerge/internal/output"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/state"
+	"github.com/UnitVectorY-Labs/ghprmerge/internal/tui"
 )
 
 // Version is set by the build system to the release version
 var Version = "dev"
 
+// exitCode is returned by run when the run completed but --fail-on selected a
+// non-zero exit code. The results have already been written.
+type exitCode int
+
+func (c exitCode) Error() string {
+	return fmt.Sprintf("exit code %d", int(c))
+}
+
 func main() {
 	// Set the build version from the build info if not set by the build system
 	if Version == "dev" || Version == "" {
@@ -37,6 +53,11 @@ func main() {
 		if errors.Is(err, config.ErrVersion) {
 			os.Exit(0)
 		}
+		// The run completed; its outcome selected a non-zero exit code
+		var code exitCode
+		if errors.As(err, &code) {
+			os.Exit(int(code))
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -54,8 +75,38 @@ func run() error {
 		return err
 	}
 
-	// Create GitHub client
-	client := github.NewRealClient(cfg.Token)
+	// Schema mode: print a JSON Schema without contacting GitHub
+	if cfg.Command == config.CommandSchema {
+		return runSchema(cfg)
+	}
+
+	// Audit mode: query the local audit log without contacting GitHub
+	if cfg.Command == config.CommandAudit {
+		return runAudit(cfg)
+	}
+
+	// History mode: summarize the local run history without contacting GitHub
+	if cfg.Command == config.CommandHistory {
+		return runHistory(cfg)
+	}
+
+	// Create GitHub client. Commands that can change pull requests record
+	// every mutation to the audit log. A log that cannot be opened only stops
+	// the run with --audit-strict.
+	var client github.Client = github.NewRealClient(cfg.Token)
+	var auditLog *audit.Log
+	if !cfg.Report && !cfg.IsAnalysisOnly() {
+		if auditLog, err = openAuditLog(cfg); err != nil {
+			if cfg.AuditStrict {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Warning: audit log disabled: %v\n", err)
+		}
+		if auditLog != nil {
+			defer auditLog.Close()
+			client = audit.NewClient(client, auditLog, commandLine())
+		}
+	}
 
 	// Create console for terminal output (nil if JSON mode)
 	var console *output.Console
@@ -65,8 +116,26 @@ func run() error {
 
 	// Create merger with console
 	m := merger.New(client, cfg, console)
+	if cfg.OutputFormat() == string(output.FormatNDJSON) {
+		m.SetEventStream(output.NewEventStream(os.Stdout))
+	}
+	if cfg.FromReport != "" {
+		selection, err := loadSelection(cfg)
+		if err != nil {
+			return err
+		}
+		m.SetSelection(selection)
+	}
+	var st *state.State
+	if cfg.StateFile != "" {
+		if st, err = openState(cfg); err != nil {
+			return err
+		}
+		m.SetState(st)
+	}
 
-	ctx := context.Background()
+	ctx, cancel := interruptContext()
+	defer cancel()
 
 	// Report mode: scan and aggregate PRs by source branch
 	if cfg.Report {
@@ -74,15 +143,126 @@ func run() error {
 	}
 
 	// Normal mode (merge, rebase, close, or analysis): act on source branches
-	return runNormal(ctx, m, cfg, console)
+	err = runNormal(ctx, m, cfg, console)
+	if stateErr := st.Err(); stateErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: checkpoints stopped: %v\n", stateErr)
+	}
+	if auditLog != nil {
+		if auditErr := auditLog.Err(); auditErr != nil {
+			if cfg.AuditStrict && err == nil {
+				return fmt.Errorf("audit log incomplete: %w", auditErr)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: audit log incomplete: %v\n", auditErr)
+		}
+	}
+	return err
+}
+
+// openAuditLog opens the audit log for a mutating command. It returns a nil
+// log when the audit log is turned off.
+func openAuditLog(cfg *config.Config) (*audit.Log, error) {
+	path, err := cfg.AuditLogPath()
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return audit.Open(path)
+}
+
+// commandLine returns the command line recorded in the audit log, quoting
+// arguments that contain spaces. Tokens are never passed as arguments.
+func commandLine() string {
+	args := make([]string, len(os.Args))
+	for i, arg := range os.Args {
+		if strings.ContainsAny(arg, " \t\"'") {
+			arg = strconv.Quote(arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// loadSelection reads the saved report and returns the pull requests in the
+// requested groups.
+func loadSelection(cfg *config.Config) (map[string][]int, error) {
+	report, err := output.ReadReport(cfg.FromReport)
+	if err != nil {
+		return nil, err
+	}
+	if report.Metadata != nil && report.Metadata.Org != "" && report.Metadata.Org != cfg.Org {
+		return nil, fmt.Errorf("%s is a report for organization %s, not %s", cfg.FromReport, report.Metadata.Org, cfg.Org)
+	}
+	selection, err := report.Select(cfg.ReportGroups)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.FromReport, err)
+	}
+	return selection, nil
+}
+
+// openState loads the state file to resume, or starts a new one. Resuming
+// from a file that does not exist yet starts a new run, so the same command
+// can be repeated until the sweep completes.
+func openState(cfg *config.Config) (*state.State, error) {
+	// A run from a report is identified by its groups instead of branches
+	branches := cfg.SourceBranches
+	if cfg.FromReport != "" {
+		branches = cfg.ReportGroups
+	}
+	if cfg.Resume {
+		st, err := state.Load(cfg.StateFile)
+		if err == nil {
+			return st, st.Matches(string(cfg.Command), cfg.Org, branches)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	st := state.New(cfg.StateFile, string(cfg.Command), cfg.Org, branches)
+	return st, st.Save()
+}
+
+// interruptContext returns a context that is cancelled by the first SIGINT or
+// SIGTERM. The run then stops starting new work and writes partial results; a
+// second signal terminates the process immediately.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig, ok := <-signals
+		if !ok {
+			return
+		}
+		signal.Stop(signals)
+		fmt.Fprintf(os.Stderr, "\nReceived %s: finishing in-flight work and writing partial results (interrupt again to exit immediately)\n", sig)
+		cancel()
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
 }
 
 // runReport executes report mode.
 func runReport(ctx context.Context, m *merger.Merger, cfg *config.Config) error {
+	// Read the previous report first so a bad path fails before the scan
+	var previous *output.ReportResult
+	if cfg.CompareReport != "" {
+		var err error
+		if previous, err = output.ReadReport(cfg.CompareReport); err != nil {
+			return err
+		}
+		if err := output.CheckComparable(previous, m.ReportMetadata()); err != nil {
+			return fmt.Errorf("cannot compare with %s: %w", cfg.CompareReport, err)
+		}
+	}
+
 	result, err := m.RunReport(ctx)
 	if err != nil {
 		return err
 	}
+	if previous != nil {
+		result.Comparison = output.CompareReports(previous, result, cfg.ReportStaleAfter())
+	}
 
 	// Determine verbosity
 	verbosity := cfg.Verbosity
@@ -90,8 +270,58 @@ func runReport(ctx context.Context, m *merger.Merger, cfg *config.Config) error
 		verbosity = "standard"
 	}
 
-	writer := output.NewWriter(os.Stdout, cfg.JSON, cfg.NoColor)
-	return writer.WriteReportResult(result, verbosity)
+	return writeOutput(cfg, func(writer *output.Writer) error {
+		return writer.WriteReportResult(result, verbosity)
+	})
+}
+
+// runSchema prints the JSON Schema of an output document.
+func runSchema(cfg *config.Config) error {
+	schema, err := output.JSONSchema(cfg.SchemaDocument, output.JSONCasing(cfg.JSONCasing))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(schema))
+	return err
+}
+
+// runAudit prints the audit log entries that match the filters.
+func runAudit(cfg *config.Config) error {
+	path, err := cfg.AuditLogPath()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("the audit log is turned off by GHPRMERGE_AUDIT_LOG=%s", audit.Off)
+	}
+	entries, err := audit.Read(path, cfg.AuditFilter)
+	if err != nil {
+		return err
+	}
+	if cfg.JSON {
+		return audit.WriteJSON(os.Stdout, entries)
+	}
+	return audit.WriteText(os.Stdout, entries)
+}
+
+// runHistory prints the trends across the recorded runs.
+func runHistory(cfg *config.Config) error {
+	path, err := cfg.HistoryFilePath()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return fmt.Errorf("the history file is turned off by GHPRMERGE_HISTORY_FILE=%s", history.Off)
+	}
+	runs, err := history.Read(path, cfg.Org, cfg.HistorySince)
+	if err != nil {
+		return err
+	}
+	trends := history.Compute(runs)
+	if cfg.JSON {
+		return history.WriteJSON(os.Stdout, trends)
+	}
+	return history.WriteText(os.Stdout, trends)
 }
 
 // runNormal executes the normal (non-report) mode.
@@ -102,11 +332,32 @@ func runNormal(ctx context.Context, m *merger.Merger, cfg *config.Config, consol
 		return err
 	}
 
+	// Dashboard mode: browse the scan result and act on individual PRs
+	if cfg.TUI {
+		dashboard := tui.New(result, m, output.NewConsole(os.Stderr, cfg.NoColor, false, true), tui.OpenBrowser)
+		if err := tui.Run(ctx, dashboard, os.Stdin, os.Stderr); err != nil {
+			if ctx.Err() == nil {
+				return err
+			}
+			result.Metadata.Interrupted = true
+		}
+		m.Summarize(result)
+		return writeResult(cfg, result)
+	}
+
 	// If confirm mode is enabled and there are actions to take, prompt user
 	if cfg.Confirm && hasActionsToPerform(result) {
+		// An interrupted scan writes its partial result instead of prompting
+		if ctx.Err() != nil || result.Metadata.Interrupted {
+			return writeInterrupted(ctx, m, cfg, result)
+		}
 		showPending := !cfg.Verbose
-		proceed, promptLines := promptConfirmation(console, result, showPending)
+		proceed, promptLines := promptConfirmation(ctx, console, result, showPending)
 		if !proceed {
+			if ctx.Err() != nil {
+				fmt.Fprintln(os.Stderr)
+				return writeInterrupted(ctx, m, cfg, result)
+			}
 			if console != nil {
 				fmt.Fprintln(os.Stderr, console.Dim("Operation cancelled by user."))
 			} else {
@@ -131,18 +382,76 @@ func runNormal(ctx context.Context, m *merger.Merger, cfg *config.Config, consol
 	}
 
 	// Output results (condensed summary for human mode, full JSON for JSON mode)
-	writer := output.NewWriter(os.Stdout, cfg.JSON, cfg.NoColor)
-	return writer.WriteResult(result)
+	return writeResult(cfg, result)
+}
+
+// writeInterrupted writes the partial result of a run interrupted before its
+// confirmed actions started. With the context cancelled, RunWithActions
+// executes nothing and records every pending action as interrupted.
+func writeInterrupted(ctx context.Context, m *merger.Merger, cfg *config.Config, result *output.RunResult) error {
+	if ctx.Err() != nil {
+		var err error
+		if result, err = m.RunWithActions(ctx, result); err != nil {
+			return err
+		}
+	}
+	result.Metadata.Interrupted = true
+	return writeResult(cfg, result)
+}
+
+// writeResult records the run in the history file, writes the run result,
+// and then applies --fail-on to it.
+func writeResult(cfg *config.Config, result *output.RunResult) error {
+	if !cfg.IsAnalysisOnly() {
+		path, err := cfg.HistoryFilePath()
+		if err == nil && path != "" {
+			err = history.Record(path, history.Run{Command: string(cfg.Command), Result: *result})
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: run not recorded in history: %v\n", err)
+		}
+	}
+	if err := writeOutput(cfg, func(writer *output.Writer) error {
+		return writer.WriteResult(result)
+	}); err != nil {
+		return err
+	}
+	if code := output.ExitCode(result, cfg.FailConditions()); code != output.ExitSuccess {
+		return exitCode(code)
+	}
+	return nil
+}
+
+// writeOutput writes results to stdout in the selected format. Markdown output
+// is also appended to the GitHub Actions step summary when running in Actions.
+func writeOutput(cfg *config.Config, write func(*output.Writer) error) error {
+	format := output.Format(cfg.OutputFormat())
+	var out io.Writer = os.Stdout
+
+	if path := output.StepSummaryPath(); path != "" && format == output.FormatMarkdown {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open GitHub step summary: %w", err)
+		}
+		defer f.Close()
+		out = io.MultiWriter(os.Stdout, f)
+	}
+
+	writer := output.NewFormatWriter(out, format, cfg.NoColor)
+	if cfg.JSONCasing != "" {
+		writer.SetJSONCasing(output.JSONCasing(cfg.JSONCasing))
+	}
+	return write(writer)
 }
 
 // hasActionsToPerform checks if the result contains actions that would be performed.
 func hasActionsToPerform(result *output.RunResult) bool {
-	return result.Summary.WouldMerge > 0 || result.Summary.WouldRebase > 0 || result.Summary.WouldClose > 0
+	return result.Summary.HasPendingActions()
 }
 
 // promptConfirmation displays pending actions and prompts for confirmation.
 // It returns whether to proceed and the number of visible terminal lines written.
-func promptConfirmation(console *output.Console, result *output.RunResult, showPending bool) (bool, int) {
+func promptConfirmation(ctx context.Context, console *output.Console, result *output.RunResult, showPending bool) (bool, int) {
 	lines := 0
 
 	if showPending && console != nil {
@@ -150,7 +459,7 @@ func promptConfirmation(console *output.Console, result *output.RunResult, showP
 		lines++
 		for _, repo := range result.Repositories {
 			for _, pr := range repo.PullRequests {
-				if pr.Action == output.ActionWouldMerge || pr.Action == output.ActionWouldRebase || pr.Action == output.ActionWouldClose {
+				if output.IsPendingAction(pr.Action) {
 					lines += console.PrintPendingAction(repo, pr)
 				}
 			}
@@ -160,7 +469,7 @@ func promptConfirmation(console *output.Console, result *output.RunResult, showP
 		lines++
 		for _, repo := range result.Repositories {
 			for _, pr := range repo.PullRequests {
-				if pr.Action == output.ActionWouldMerge || pr.Action == output.ActionWouldRebase || pr.Action == output.ActionWouldClose {
+				if output.IsPendingAction(pr.Action) {
 					fmt.Fprintf(os.Stderr, "  %s #%d %s ─ %s\n", repo.FullName, pr.Number, pr.Title, pr.Action)
 					lines++
 				}
@@ -172,15 +481,103 @@ func promptConfirmation(console *output.Console, result *output.RunResult, showP
 		fmt.Fprintln(os.Stderr)
 		lines++
 	}
-	fmt.Fprint(os.Stderr, "Do you want to proceed? [y/N]: ")
+	fmt.Fprint(os.Stderr, "Do you want to proceed? [y/N/s to select]: ")
 	lines++
 
 	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
+	input, err := readLine(ctx, reader)
 	if err != nil {
 		return false, lines
 	}
 
+	switch strings.TrimSpace(strings.ToLower(input)) {
+	case "y", "yes":
+		return true, lines
+	case "s", "select":
+		proceed, selectLines := promptSelection(ctx, reader, console, result)
+		return proceed, lines + selectLines
+	}
+	return false, lines
+}
+
+// promptSelection lists the pending actions by number and asks which to
+// deselect, such as "1-10,12" or a repository name. Deselected actions are
+// recorded as skipped. It returns whether to proceed with the remaining
+// actions, which is true without asking when all of them were deselected, and
+// the number of visible terminal lines written.
+func promptSelection(ctx context.Context, reader *bufio.Reader, console *output.Console, result *output.RunResult) (bool, int) {
+	pending := output.PendingActions(result)
+	lines := 0
+
+	fmt.Fprintln(os.Stderr)
+	lines++
+	for i, ref := range pending {
+		repo := result.Repositories[ref.Repo]
+		pr := repo.PullRequests[ref.PullRequest]
+		if console != nil {
+			lines += console.PrintNumberedPendingAction(i+1, repo, pr)
+		} else {
+			fmt.Fprintf(os.Stderr, "  %3d %s #%d %s ─ %s\n", i+1, repo.FullName, pr.Number, pr.Title, pr.Action)
+			lines++
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+	lines++
+
+	var deselected []output.PendingRef
+	for {
+		fmt.Fprint(os.Stderr, "Deselect (e.g. 1-10,12 or a repository name; Enter keeps all): ")
+		lines++
+		input, err := readLine(ctx, reader)
+		if err != nil {
+			return false, lines
+		}
+		deselected, err = output.ParseSelection(input, result, pending)
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "  %v\n", err)
+		lines++
+	}
+
+	// With every action deselected there is nothing left to confirm; the run
+	// still completes so the deselected PRs are written and recorded.
+	remaining := len(pending) - len(deselected)
+	if remaining == 0 {
+		output.Deselect(result, deselected)
+		return true, lines
+	}
+	fmt.Fprintf(os.Stderr, "Proceed with %d of %d actions? [y/N]: ", remaining, len(pending))
+	lines++
+	input, err := readLine(ctx, reader)
+	if err != nil {
+		return false, lines
+	}
 	input = strings.TrimSpace(strings.ToLower(input))
-	return input == "y" || input == "yes", lines
+	if input != "y" && input != "yes" {
+		return false, lines
+	}
+
+	output.Deselect(result, deselected)
+	return true, lines
+}
+
+// readLine reads a line from the prompt input. It gives up when ctx is
+// cancelled, so an interrupt at a prompt cancels it instead of being ignored.
+func readLine(ctx context.Context, reader *bufio.Reader) (string, error) {
+	type line struct {
+		text string
+		err  error
+	}
+	lines := make(chan line, 1)
+	go func() {
+		text, err := reader.ReadString('\n')
+		lines <- line{text, err}
+	}()
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case l := <-lines:
+		return l.text, l.err
+	}
 }